@@ -0,0 +1,67 @@
+// Command reconcile checks the ledger invariants and writes a JSON report.
+// It exits with status 1 when any invariant is violated.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+	"github.com/AdityaTote/wallet-service/internal/database"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/AdityaTote/wallet-service/internal/server"
+	"github.com/AdityaTote/wallet-service/internal/service"
+	"github.com/rs/zerolog"
+)
+
+func main() {
+	out := flag.String("out", "", "write the report to this file instead of stdout")
+	flag.Parse()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	ctx := context.Background()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
+	}
+
+	db, err := database.New(ctx, *cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to connect to database")
+	}
+	defer db.Close()
+
+	repo := repository.NewRepository(db.Pool)
+	svc := service.New(ctx, cfg, log, server.New(cfg, db), repo)
+
+	report, err := svc.Reconcile().Reconcile()
+	if err != nil {
+		log.Fatal().Err(err).Msg("reconciliation failed")
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to create report file")
+		}
+		defer f.Close()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Fatal().Err(err).Msg("failed to write report")
+	}
+
+	if !report.Consistent {
+		log.Error().Msg("ledger invariants violated")
+		db.Close()
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,108 @@
+// Command seed creates the data a fresh database needs: the UC asset, its
+// SYSTEM, PROMOTION and TREASURY wallets, the default limits of each tier, and a few
+// demo users. Running it again is
+// safe; anything that already exists is left alone.
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+	"github.com/AdityaTote/wallet-service/internal/database"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/AdityaTote/wallet-service/internal/server"
+	"github.com/AdityaTote/wallet-service/internal/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+type seedUser struct {
+	username string
+	password string
+	balance int64
+}
+
+var seedUsers = []seedUser{
+	{username: "alice", password: "password123", balance: 10000},
+	{username: "bob", password: "password456", balance: 5000},
+	{username: "charlie", password: "password789", balance: 20000},
+}
+
+func main() {
+	ctx := context.Background()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	db, err := database.New(ctx, *cfg)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewRepository(db.Pool)
+	svc := service.New(ctx, cfg, zerolog.Nop(), server.New(cfg, db), repo)
+
+	// creating the asset also creates its system wallets and tier limits
+	_, err = svc.Asset().Create(&models.AssetRequest{
+		Code: config.AssetCodeUC,
+		Name: "Universal Credits",
+	})
+	switch {
+	case errors.Is(err, models.ErrAssetExists):
+		log.Printf("asset %s already exists, left unchanged", config.AssetCodeUC)
+	case err != nil:
+		log.Fatalf("failed to seed asset: %v", err)
+	default:
+		log.Printf("asset %s, its system wallets and tier limits are ready", config.AssetCodeUC)
+	}
+
+	for _, user := range seedUsers {
+		if err := seed(ctx, repo, svc, user); err != nil {
+			log.Fatalf("failed to seed user %s: %v", user.username, err)
+		}
+	}
+}
+
+// seed signs a user up like any other client and tops their wallet up to the
+// seed balance. Users that already exist are skipped.
+func seed(ctx context.Context, repo *repository.Repository, svc service.Services, user seedUser) error {
+	_, err := repo.Queries().GetUserByUsername(ctx, user.username)
+	if err == nil {
+		log.Printf("user %s already exists, skipping", user.username)
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	created, err := svc.Auth().Signup(models.UserParams{
+		Username: user.username,
+		Password: user.password,
+	})
+	if err != nil {
+		return err
+	}
+
+	if topUp := user.balance - *created.Balance; topUp > 0 {
+		_, err = svc.Wallet().TopUp(&models.WalletServiceParams{
+			UserId: created.Id,
+			WalletRequest: models.WalletRequest{
+				TxnId: uuid.New(),
+				Amount: models.Amount{Digits: topUp},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("created user %s with %d %s", user.username, user.balance, config.AssetCodeUC)
+	return nil
+}
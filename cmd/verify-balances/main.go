@@ -0,0 +1,70 @@
+// Command verify-balances proves that the cached balances in wallet_balances
+// match the ledger. It prints a JSON report and exits with status 1 when any
+// wallet disagrees.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+	"github.com/AdityaTote/wallet-service/internal/database"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/AdityaTote/wallet-service/internal/server"
+	"github.com/AdityaTote/wallet-service/internal/service"
+	"github.com/rs/zerolog"
+)
+
+func main() {
+	checkpoint := flag.Bool("checkpoint", false, "fold shared wallets' balance changes and write a checkpoint for every user wallet with new ledger entries before verifying")
+	flag.Parse()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	ctx := context.Background()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
+	}
+
+	db, err := database.New(ctx, *cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to connect to database")
+	}
+	defer db.Close()
+
+	repo := repository.NewRepository(db.Pool)
+	svc := service.New(ctx, cfg, log, server.New(cfg, db), repo)
+
+	if *checkpoint {
+		folded, err := svc.Balance().Fold()
+		if err != nil {
+			log.Error().Err(err).Msg("fold failed")
+		}
+		log.Info().Int64("folded", folded).Msg("balance changes folded")
+
+		written, err := svc.Balance().Checkpoint(1)
+		if err != nil {
+			log.Error().Err(err).Msg("checkpoint failed")
+		}
+		log.Info().Int("written", written).Msg("checkpoints written")
+	}
+
+	report, err := svc.Balance().Verify()
+	if err != nil {
+		log.Fatal().Err(err).Msg("verification failed")
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Fatal().Err(err).Msg("failed to write report")
+	}
+
+	if !report.Consistent {
+		db.Close()
+		os.Exit(1)
+	}
+}
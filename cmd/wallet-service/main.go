@@ -0,0 +1,116 @@
+// Command wallet-service runs the wallet HTTP API.
+//
+//	wallet-service                  serve the API
+//	wallet-service migrate <cmd>    apply or inspect the embedded migrations
+//	wallet-service role <user> <R>  set a user's role, e.g. to grant the first admin
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+	"github.com/AdityaTote/wallet-service/internal/database"
+	"github.com/AdityaTote/wallet-service/internal/handler"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/AdityaTote/wallet-service/internal/router"
+	"github.com/AdityaTote/wallet-service/internal/server"
+	"github.com/AdityaTote/wallet-service/internal/service"
+	"github.com/AdityaTote/wallet-service/migrations"
+	"github.com/rs/zerolog"
+)
+
+func main() {
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+	// signals only start the shutdown; requests keep the service context
+	// until the server has drained
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load config")
+	}
+
+	db, err := database.New(ctx, *cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to connect to database")
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		code := runMigrate(signalCtx, db, log, os.Args[2:])
+		db.Close()
+		os.Exit(code)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "role" {
+		code := runRole(ctx, cfg, db, log, os.Args[2:])
+		db.Close()
+		os.Exit(code)
+	}
+
+	if cfg.MigrateOnStart {
+		migrator, err := database.NewMigrator(db, migrations.Files)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load migrations")
+		}
+
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to apply migrations")
+		}
+		log.Info().Int("applied", len(applied)).Msg("migrations are up to date")
+	}
+
+	repo := repository.NewRepository(db.Pool)
+	srv := server.New(cfg, db)
+	svc := service.New(ctx, cfg, log, srv, repo)
+	h := handler.New(svc, cfg, log)
+
+	srv.SetUpHttpServer(router.New(h, srv, repo, log))
+
+	// background work stops once requests have drained, before the pool closes
+	srv.OnDrained(cancel)
+
+	go svc.Balance().RunCheckpointer(config.BalanceCheckpointInterval, config.BalanceCheckpointMinEntries)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Info().Msgf("Server started on http://localhost:%s", cfg.Port)
+		if err := srv.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case <-signalCtx.Done():
+		log.Info().Msg("shutdown signal received")
+	case err := <-serverErr:
+		log.Error().Err(err).Msg("http server failed")
+	}
+	stop()
+
+	// fail the readiness probe first so load balancers stop sending traffic
+	// while the listener is still open
+	srv.SetReady(false)
+	log.Info().Dur("drain_delay", cfg.ShutdownDrainDelay).Msg("readiness cleared, waiting before draining")
+	time.Sleep(cfg.ShutdownDrainDelay)
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelShutdown()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("failed to shut down cleanly")
+	}
+
+	log.Info().Msg("server stopped")
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/AdityaTote/wallet-service/internal/database"
+	"github.com/AdityaTote/wallet-service/migrations"
+	"github.com/rs/zerolog"
+)
+
+const migrateUsage = "usage: wallet-service migrate up | down [N] | status | version"
+
+// runMigrate implements the migrate subcommand. It returns the exit status.
+func runMigrate(ctx context.Context, db *database.Database, log zerolog.Logger, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	migrator, err := database.NewMigrator(db, migrations.Files)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load migrations")
+		return 1
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("migrate up failed")
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("no change")
+		}
+
+	case "down":
+		// revert one migration unless told otherwise, never everything by default
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				fmt.Fprintln(os.Stderr, migrateUsage)
+				return 2
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Printf("reverted %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("migrate down failed")
+			return 1
+		}
+		if len(reverted) == 0 {
+			fmt.Println("no change")
+		}
+
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to read migration status")
+			return 1
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tSTATE\tNAME")
+		for _, m := range status {
+			state := "pending"
+			if m.Applied {
+				state = "applied"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", m.Version, state, m.Name)
+		}
+		w.Flush()
+
+	case "version":
+		version, dirty, err := migrator.Version(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to read schema version")
+			return 1
+		}
+
+		if dirty {
+			fmt.Printf("%d (dirty)\n", version)
+		} else {
+			fmt.Println(version)
+		}
+
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	return 0
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+	"github.com/AdityaTote/wallet-service/internal/database"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/AdityaTote/wallet-service/internal/server"
+	"github.com/AdityaTote/wallet-service/internal/service"
+	"github.com/rs/zerolog"
+)
+
+const roleUsage = "usage: wallet-service role <username|id> USER | SUPPORT | ADMIN | FINANCE"
+
+// runRole implements the role subcommand, which grants the first admin
+// before anyone can use PUT /api/admin/users/{id}/role. It returns the exit
+// status.
+func runRole(ctx context.Context, cfg *config.Config, db *database.Database, log zerolog.Logger, args []string) int {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, roleUsage)
+		return 2
+	}
+
+	role := models.Role(strings.ToUpper(args[1]))
+	if !role.Valid() {
+		fmt.Fprintln(os.Stderr, roleUsage)
+		return 2
+	}
+
+	svc := service.New(ctx, cfg, log, server.New(cfg, db), repository.NewRepository(db.Pool)).Admin()
+
+	user, err := svc.GetUser(args[0])
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			fmt.Fprintf(os.Stderr, "user %s not found\n", args[0])
+			return 1
+		}
+		log.Error().Err(err).Msg("failed to get user")
+		return 1
+	}
+
+	if _, err := svc.SetRole(user.Id, role); err != nil {
+		log.Error().Err(err).Msg("failed to set role")
+		return 1
+	}
+
+	fmt.Printf("%s is now %s\n", user.Username, role)
+	return 0
+}
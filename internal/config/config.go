@@ -1,8 +1,16 @@
 package config
 
 import (
+	_ "embed"
+	"fmt"
+	"net/http"
+	"net/netip"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
 	"github.com/go-playground/validator/v10"
 	"github.com/knadh/koanf/parsers/dotenv"
 	"github.com/knadh/koanf/providers/env"
@@ -10,6 +18,12 @@ import (
 	"github.com/knadh/koanf/v2"
 )
 
+// bannedPasswords is the built-in list of common passwords refused by the
+// password policy
+//
+//go:embed banned_passwords.txt
+var bannedPasswords string
+
 type Config struct {
 	Port   string `koanf:"PORT" validate:"required"`
 	DbHost string `koanf:"DATABASE_HOST" validate:"required"`
@@ -17,7 +31,73 @@ type Config struct {
 	DbUser string `koanf:"DATABASE_USER" validate:"required"`
 	DbPassword string `koanf:"DATABASE_PASSWORD" validate:"required"`
 	DbName string `koanf:"DATABASE_NAME" validate:"required"`
-	JWTSecret string `koanf:"JWT_SECRET" validate:"required"`
+	// JWTSecret signs access tokens with HS256 when no signing key is set,
+	// and keeps HS256 tokens valid while moving to one
+	JWTSecret string `koanf:"JWT_SECRET" validate:"required_without=JWTSigningKeyFile"`
+	// JWTSigningKeyFile is a PEM file with the RSA or Ed25519 private key
+	// access tokens are signed with
+	JWTSigningKeyFile string `koanf:"JWT_SIGNING_KEY_FILE"`
+	// JWTSigningKeyID is the kid header of tokens signed with JWTSigningKeyFile
+	JWTSigningKeyID string `koanf:"JWT_SIGNING_KEY_ID" validate:"required_with=JWTSigningKeyFile"`
+	// JWTVerificationKeys lists the public keys of retired signing keys that
+	// still verify tokens, e.g. "2026-01:/keys/2026-01.pub.pem"
+	JWTVerificationKeys string `koanf:"JWT_VERIFICATION_KEYS"`
+	// TokenKeys is the key set built from the JWT settings
+	TokenKeys *utils.KeySet `koanf:"-"`
+	// AccessTokenTTL is how long an access token is valid
+	AccessTokenTTL time.Duration `koanf:"ACCESS_TOKEN_TTL"`
+	// RefreshTokenTTL is how long a session lives without being refreshed
+	RefreshTokenTTL time.Duration `koanf:"REFRESH_TOKEN_TTL"`
+	// LoginLockoutThreshold is how many failed signins lock a username
+	LoginLockoutThreshold int `koanf:"LOGIN_LOCKOUT_THRESHOLD" validate:"gte=0"`
+	// LoginIPLockoutThreshold is how many failed signins lock a client IP
+	LoginIPLockoutThreshold int `koanf:"LOGIN_IP_LOCKOUT_THRESHOLD" validate:"gte=0"`
+	// LoginLockoutDuration is how long a locked username or IP must wait,
+	// and the longest backoff before the lockout
+	LoginLockoutDuration time.Duration `koanf:"LOGIN_LOCKOUT_DURATION"`
+	// LoginBackoffBase is the first backoff delay; it doubles with every
+	// further failure
+	LoginBackoffBase time.Duration `koanf:"LOGIN_BACKOFF_BASE"`
+	// TrustedProxies lists the addresses or CIDR ranges of the proxies whose
+	// X-Forwarded-For header is believed, e.g. "10.0.0.0/8,192.168.1.10"
+	TrustedProxies string `koanf:"TRUSTED_PROXIES"`
+	// TrustedProxyPrefixes is TrustedProxies parsed into prefixes
+	TrustedProxyPrefixes []netip.Prefix `koanf:"-"`
+	// PasswordMinLength is the shortest password accepted for a new account
+	// or a password change
+	PasswordMinLength int `koanf:"PASSWORD_MIN_LENGTH" validate:"gte=0,lte=72"`
+	// PasswordMinClasses is how many of lowercase, uppercase, digits and
+	// symbols a new password must mix
+	PasswordMinClasses int `koanf:"PASSWORD_MIN_CLASSES" validate:"gte=0,lte=4"`
+	// PasswordBannedFile adds passwords, one per line, to the built-in list
+	// of passwords that are refused
+	PasswordBannedFile string `koanf:"PASSWORD_BANNED_FILE"`
+	// BannedPasswords is the built-in list and PasswordBannedFile, lowercased
+	BannedPasswords map[string]struct{} `koanf:"-"`
+	// CookieSecure marks session cookies Secure, so browsers only send them
+	// over HTTPS
+	CookieSecure bool `koanf:"COOKIE_SECURE"`
+	// CookieSameSite is the SameSite attribute of session cookies: lax,
+	// strict or none
+	CookieSameSite string `koanf:"COOKIE_SAME_SITE" validate:"omitempty,oneof=lax strict none"`
+	// CookieSameSiteMode is CookieSameSite parsed for net/http
+	CookieSameSiteMode http.SameSite `koanf:"-"`
+	// CookieDomain is the Domain attribute of session cookies; empty means
+	// the host that set them
+	CookieDomain string `koanf:"COOKIE_DOMAIN"`
+	// SignupBonus lists the bonus credited at signup per asset in minor units,
+	// e.g. "UC:1000,EUR:500"
+	SignupBonus string `koanf:"SIGNUP_BONUS"`
+	// SignupBonuses is SignupBonus parsed into amounts keyed by asset code
+	SignupBonuses map[string]int64 `koanf:"-"`
+	// ShutdownTimeout bounds how long in-flight requests may run after a
+	// shutdown signal
+	ShutdownTimeout time.Duration `koanf:"SHUTDOWN_TIMEOUT"`
+	// ShutdownDrainDelay is how long the readiness probe reports not ready
+	// before the server stops accepting connections
+	ShutdownDrainDelay time.Duration `koanf:"SHUTDOWN_DRAIN_DELAY"`
+	// MigrateOnStart applies pending migrations before the server starts
+	MigrateOnStart bool `koanf:"MIGRATE_ON_START"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -44,5 +124,201 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.SignupBonus == "" {
+		cfg.SignupBonus = DefaultSignupBonus
+	}
+	bonuses, err := parseAssetAmounts(cfg.SignupBonus)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SIGNUP_BONUS: %w", err)
+	}
+	cfg.SignupBonuses = bonuses
+
+	verificationKeys, err := parseKeyFiles(cfg.JWTVerificationKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT_VERIFICATION_KEYS: %w", err)
+	}
+	keys, err := utils.LoadKeySet(cfg.JWTSecret, cfg.JWTSigningKeyFile, cfg.JWTSigningKeyID, verificationKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT keys: %w", err)
+	}
+	cfg.TokenKeys = keys
+
+	if !k.Exists("ACCESS_TOKEN_TTL") {
+		cfg.AccessTokenTTL = DefaultAccessTokenTTL
+	}
+	if !k.Exists("REFRESH_TOKEN_TTL") {
+		cfg.RefreshTokenTTL = DefaultRefreshTokenTTL
+	}
+
+	if !k.Exists("LOGIN_LOCKOUT_THRESHOLD") {
+		cfg.LoginLockoutThreshold = DefaultLoginLockoutThreshold
+	}
+	if !k.Exists("LOGIN_IP_LOCKOUT_THRESHOLD") {
+		cfg.LoginIPLockoutThreshold = DefaultLoginIPLockoutThreshold
+	}
+	if !k.Exists("LOGIN_LOCKOUT_DURATION") {
+		cfg.LoginLockoutDuration = DefaultLoginLockoutDuration
+	}
+	if !k.Exists("LOGIN_BACKOFF_BASE") {
+		cfg.LoginBackoffBase = DefaultLoginBackoffBase
+	}
+
+	proxies, err := parsePrefixes(cfg.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	cfg.TrustedProxyPrefixes = proxies
+
+	if !k.Exists("PASSWORD_MIN_LENGTH") {
+		cfg.PasswordMinLength = DefaultPasswordMinLength
+	}
+	if !k.Exists("PASSWORD_MIN_CLASSES") {
+		cfg.PasswordMinClasses = DefaultPasswordMinClasses
+	}
+	banned, err := loadBannedPasswords(cfg.PasswordBannedFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_BANNED_FILE: %w", err)
+	}
+	cfg.BannedPasswords = banned
+
+	if !k.Exists("COOKIE_SECURE") {
+		cfg.CookieSecure = true
+	}
+	if cfg.CookieSameSite == "" {
+		cfg.CookieSameSite = DefaultCookieSameSite
+	}
+	switch cfg.CookieSameSite {
+	case "strict":
+		cfg.CookieSameSiteMode = http.SameSiteStrictMode
+	case "none":
+		// browsers drop SameSite=None cookies that are not Secure
+		if !cfg.CookieSecure {
+			return nil, fmt.Errorf("COOKIE_SAME_SITE=none requires COOKIE_SECURE=true")
+		}
+		cfg.CookieSameSiteMode = http.SameSiteNoneMode
+	default:
+		cfg.CookieSameSiteMode = http.SameSiteLaxMode
+	}
+
+	if !k.Exists("SHUTDOWN_TIMEOUT") {
+		cfg.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	if !k.Exists("SHUTDOWN_DRAIN_DELAY") {
+		cfg.ShutdownDrainDelay = DefaultShutdownDrainDelay
+	}
+
 	return &cfg, nil
+}
+
+// parseAssetAmounts parses a comma separated list of CODE:AMOUNT pairs.
+func parseAssetAmounts(value string) (map[string]int64, error) {
+	amounts := map[string]int64{}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		code, amount, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("%q is not CODE:AMOUNT", pair)
+		}
+
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			return nil, fmt.Errorf("%q has no asset code", pair)
+		}
+
+		n, err := strconv.ParseInt(strings.TrimSpace(amount), 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%q has an invalid amount", pair)
+		}
+
+		amounts[code] = n
+	}
+
+	return amounts, nil
+}
+
+// parseKeyFiles parses a comma separated list of KID:PATH pairs.
+func parseKeyFiles(value string) (map[string]string, error) {
+	files := map[string]string{}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kid, path, ok := strings.Cut(pair, ":")
+		kid, path = strings.TrimSpace(kid), strings.TrimSpace(path)
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("%q is not KID:PATH", pair)
+		}
+		if _, dup := files[kid]; dup {
+			return nil, fmt.Errorf("key id %q is listed twice", kid)
+		}
+
+		files[kid] = path
+	}
+
+	return files, nil
+}
+
+// parsePrefixes parses a comma separated list of IP addresses and CIDR
+// ranges; an address is a range of one.
+func parsePrefixes(value string) ([]netip.Prefix, error) {
+	prefixes := []netip.Prefix{}
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if strings.Contains(item, "/") {
+			prefix, err := netip.ParsePrefix(item)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a CIDR range", item)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(item)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP address", item)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return prefixes, nil
+}
+
+// loadBannedPasswords reads the built-in banned password list and, when
+// file is set, the passwords listed in it.
+func loadBannedPasswords(file string) (map[string]struct{}, error) {
+	banned := map[string]struct{}{}
+
+	lists := []string{bannedPasswords}
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, string(data))
+	}
+
+	for _, list := range lists {
+		for _, line := range strings.Split(list, "\n") {
+			password := strings.ToLower(strings.TrimSpace(line))
+			if password != "" {
+				banned[password] = struct{}{}
+			}
+		}
+	}
+
+	return banned, nil
 }
\ No newline at end of file
@@ -1,6 +1,50 @@
 package config
 
+import "time"
+
 const (
 	AssetCodeUC = "UC"
-	InitialBonusAmount = 1000
+	// DefaultAssetCode is used when a wallet request does not name an asset
+	DefaultAssetCode = AssetCodeUC
+	// DefaultSignupBonus is used when SIGNUP_BONUS is not set
+	DefaultSignupBonus = AssetCodeUC + ":1000"
+	// DefaultHoldTTL is how long a hold reserves funds when the request does not say
+	DefaultHoldTTL = 15 * time.Minute
+	// DefaultQuoteTTL is how long a conversion quote holds its rate when the
+	// request does not say
+	DefaultQuoteTTL = 30 * time.Second
+	// BalanceCheckpointInterval is how often wallets are checkpointed
+	BalanceCheckpointInterval = 5 * time.Minute
+	// BalanceCheckpointMinEntries is how many ledger entries a wallet needs
+	// since its last checkpoint before a new one is written
+	BalanceCheckpointMinEntries = 100
+	// DefaultShutdownTimeout is used when SHUTDOWN_TIMEOUT is not set
+	DefaultShutdownTimeout = 15 * time.Second
+	// DefaultShutdownDrainDelay is used when SHUTDOWN_DRAIN_DELAY is not set
+	DefaultShutdownDrainDelay = 5 * time.Second
+	// DefaultAccessTokenTTL is used when ACCESS_TOKEN_TTL is not set
+	DefaultAccessTokenTTL = 15 * time.Minute
+	// DefaultRefreshTokenTTL is used when REFRESH_TOKEN_TTL is not set
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+	// DefaultLoginLockoutThreshold is used when LOGIN_LOCKOUT_THRESHOLD is not set
+	DefaultLoginLockoutThreshold = 10
+	// DefaultLoginIPLockoutThreshold is used when LOGIN_IP_LOCKOUT_THRESHOLD is not set
+	DefaultLoginIPLockoutThreshold = 100
+	// DefaultLoginLockoutDuration is used when LOGIN_LOCKOUT_DURATION is not set
+	DefaultLoginLockoutDuration = 15 * time.Minute
+	// DefaultLoginBackoffBase is used when LOGIN_BACKOFF_BASE is not set
+	DefaultLoginBackoffBase = time.Second
+	// LoginFreeAttempts is how many failed signins go without backoff
+	LoginFreeAttempts = 3
+	// LoginAttemptWindow is how long failed signins are remembered after
+	// the last one
+	LoginAttemptWindow = 24 * time.Hour
+	// DefaultPasswordMinLength is used when PASSWORD_MIN_LENGTH is not set
+	DefaultPasswordMinLength = 10
+	// DefaultPasswordMinClasses is used when PASSWORD_MIN_CLASSES is not set
+	DefaultPasswordMinClasses = 2
+	// PasswordMaxLength is the longest password bcrypt can hash, in bytes
+	PasswordMaxLength = 72
+	// DefaultCookieSameSite is used when COOKIE_SAME_SITE is not set
+	DefaultCookieSameSite = "lax"
 )
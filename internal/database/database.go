@@ -46,6 +46,6 @@ func New(ctx context.Context, cfg config.Config) (*Database, error) {
 }
 
 func (db *Database) Close() error {
-	db.Close()
+	db.Pool.Close()
 	return nil
 }
\ No newline at end of file
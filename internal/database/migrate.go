@@ -0,0 +1,287 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationLockKey is the advisory lock held while migrating, so replicas
+// that start at the same time apply migrations one after the other.
+const migrationLockKey int64 = 7343112950244019
+
+// schema_migrations uses the layout of golang-migrate, so databases migrated
+// with the migrate CLI keep their version.
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version BIGINT NOT NULL PRIMARY KEY,
+  dirty BOOLEAN NOT NULL
+)`
+
+var ErrDirtyDatabase = errors.New("database is dirty: a migration failed partway, fix the schema and the schema_migrations row by hand")
+
+// Migration is one pair of <version>_<name>.up.sql and .down.sql files.
+type Migration struct {
+	Version int64
+	Name string
+	up string
+	down string
+}
+
+// MigrationStatus reports whether a migration is applied.
+type MigrationStatus struct {
+	Version int64 `json:"version"`
+	Name string `json:"name"`
+	Applied bool `json:"applied"`
+}
+
+// Migrator applies the embedded migrations and records the schema version in
+// schema_migrations.
+type Migrator struct {
+	pool *pgxpool.Pool
+	files fs.FS
+	migrations []Migration
+}
+
+func NewMigrator(db *Database, files fs.FS) (*Migrator, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		prefix, rest, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %q has no version prefix", name)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %q has an invalid version", name)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{
+				Version: version,
+				Name: strings.TrimSuffix(rest, "."+direction+".sql"),
+			}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.up = name
+		} else {
+			m.down = name
+		}
+	}
+
+	migrator := &Migrator{
+		pool: db.Pool,
+		files: files,
+	}
+	for _, m := range byVersion {
+		if m.up == "" {
+			return nil, fmt.Errorf("migration %d has no up file", m.Version)
+		}
+		migrator.migrations = append(migrator.migrations, *m)
+	}
+	sort.Slice(migrator.migrations, func(i, j int) bool {
+		return migrator.migrations[i].Version < migrator.migrations[j].Version
+	})
+
+	return migrator, nil
+}
+
+// Up applies every migration newer than the current version and returns the
+// ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		version, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("version %d: %w", version, ErrDirtyDatabase)
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, migration.up, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the latest steps applied migrations and returns the ones it
+// reverted, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		version, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("version %d: %w", version, ErrDirtyDatabase)
+		}
+		if version == 0 {
+			return nil
+		}
+
+		current := sort.Search(len(m.migrations), func(i int) bool {
+			return m.migrations[i].Version >= version
+		})
+		if current == len(m.migrations) || m.migrations[current].Version != version {
+			return fmt.Errorf("database version %d has no migration file", version)
+		}
+
+		for i := current; i > current-steps && i >= 0; i-- {
+			migration := m.migrations[i]
+			if migration.down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			var previous int64
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+
+			if err := m.apply(ctx, conn, migration.down, previous); err != nil {
+				return fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Version returns the current schema version, 0 when nothing is applied.
+func (m *Migrator) Version(ctx context.Context) (int64, bool, error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, createSchemaMigrations); err != nil {
+		return 0, false, err
+	}
+
+	return readVersion(ctx, conn)
+}
+
+// Status lists every embedded migration and whether it is applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status = append(status, MigrationStatus{
+			Version: migration.Version,
+			Name: migration.Name,
+			Applied: migration.Version <= version,
+		})
+	}
+
+	return status, nil
+}
+
+// withLock runs fn on one connection while holding the migration advisory
+// lock. The lock is session level, so it has to stay on that connection.
+func (m *Migrator) withLock(ctx context.Context, fn func(*pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	// unlock even when ctx is already cancelled
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
+	if _, err := conn.Exec(ctx, createSchemaMigrations); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// apply runs one migration file and records the resulting version in the same
+// transaction, so a failed migration leaves neither schema changes nor a
+// dirty version behind.
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, file string, version int64) error {
+	body, err := fs.ReadFile(m.files, file)
+	if err != nil {
+		return err
+	}
+
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if strings.TrimSpace(string(body)) != "" {
+			if _, err := tx.Exec(ctx, string(body)); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations"); err != nil {
+			return err
+		}
+		if version == 0 {
+			return nil
+		}
+		_, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", version)
+		return err
+	})
+}
+
+func readVersion(ctx context.Context, conn *pgxpool.Conn) (int64, bool, error) {
+	var version int64
+	var dirty bool
+
+	err := conn.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	return version, dirty, nil
+}
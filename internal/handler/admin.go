@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/service"
+	"github.com/AdityaTote/wallet-service/internal/validations"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+)
+
+type AdminHandler interface {
+	Reconcile(w http.ResponseWriter, r *http.Request)
+	ListCampaigns(w http.ResponseWriter, r *http.Request)
+	CreateCampaign(w http.ResponseWriter, r *http.Request)
+	GetCampaign(w http.ResponseWriter, r *http.Request)
+	UpdateCampaign(w http.ResponseWriter, r *http.Request)
+	EndCampaign(w http.ResponseWriter, r *http.Request)
+	GrantBonus(w http.ResponseWriter, r *http.Request)
+	GetUser(w http.ResponseWriter, r *http.Request)
+	SetUserRole(w http.ResponseWriter, r *http.Request)
+	GetWallet(w http.ResponseWriter, r *http.Request)
+	GetWalletTransactions(w http.ResponseWriter, r *http.Request)
+	GetWalletStatusHistory(w http.ResponseWriter, r *http.Request)
+	SetWalletStatus(w http.ResponseWriter, r *http.Request)
+	CloseWallet(w http.ResponseWriter, r *http.Request)
+	GetUserLimits(w http.ResponseWriter, r *http.Request)
+	SetUserLimitTier(w http.ResponseWriter, r *http.Request)
+	SetUserTransactionLimit(w http.ResponseWriter, r *http.Request)
+	DeleteUserTransactionLimit(w http.ResponseWriter, r *http.Request)
+	SetUserBalanceLimit(w http.ResponseWriter, r *http.Request)
+	DeleteUserBalanceLimit(w http.ResponseWriter, r *http.Request)
+	ListAssets(w http.ResponseWriter, r *http.Request)
+	CreateAsset(w http.ResponseWriter, r *http.Request)
+	GetAsset(w http.ResponseWriter, r *http.Request)
+	UpdateAsset(w http.ResponseWriter, r *http.Request)
+	DeactivateAsset(w http.ResponseWriter, r *http.Request)
+	ListFxRates(w http.ResponseWriter, r *http.Request)
+	CreateFxRate(w http.ResponseWriter, r *http.Request)
+	GetFxRate(w http.ResponseWriter, r *http.Request)
+	EndFxRate(w http.ResponseWriter, r *http.Request)
+}
+
+type admin struct {
+	svc service.AdminService
+	reconcile service.ReconcileService
+	campaign service.CampaignService
+	wallet service.WalletService
+	limit service.LimitService
+	asset service.AssetService
+	fx service.FxService
+	log zerolog.Logger
+}
+
+func (h *admin) Reconcile(w http.ResponseWriter, r *http.Request) {
+	data, err := h.reconcile.Reconcile()
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to reconcile ledger")
+		h.writeError(w, err)
+		return
+	}
+
+	message := "ledger is consistent"
+	if !data.Consistent {
+		message = "ledger invariants violated"
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: message,
+		Data:    data,
+	})
+}
+
+// GetUser looks a user up by id or username.
+func (h *admin) GetUser(w http.ResponseWriter, r *http.Request) {
+	data, err := h.svc.GetUser(chi.URLParam(r, "user"))
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get user")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "user retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	userId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateRoleInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// an admin demoting themselves could leave nobody to grant roles
+	caller := r.Context().Value("user").(models.User)
+	if caller.Id == userId && models.Role(input.Role) != caller.Role {
+		h.writeError(w, models.ErrOwnRoleChange)
+		return
+	}
+
+	data, err := h.svc.SetRole(userId, models.Role(input.Role))
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to set user role")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "user role updated successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) GetWallet(w http.ResponseWriter, r *http.Request) {
+	walletId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.GetWallet(walletId)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get wallet")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "wallet retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) GetWalletTransactions(w http.ResponseWriter, r *http.Request) {
+	walletId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateTransactionHistoryInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.WalletHistory(walletId, input)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get wallet transactions")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "wallet transactions retrieved successfully",
+		Data:    data,
+	})
+}
+
+// SetWalletStatus freezes or unfreezes a wallet. Closing has its own
+// route and permission because it moves money.
+func (h *admin) SetWalletStatus(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value("user").(models.User)
+
+	walletId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateWalletStatusInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.SetWalletStatus(&models.WalletStatusParams{
+		WalletId: walletId,
+		Status:   input.Status,
+		Reason:   input.Reason,
+		ActorId:  user.Id,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to change wallet status")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "wallet status changed successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) CloseWallet(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value("user").(models.User)
+
+	walletId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateWalletCloseInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.SetWalletStatus(&models.WalletStatusParams{
+		WalletId: walletId,
+		Status:   models.WalletStatusClosed,
+		Reason:   input.Reason,
+		ActorId:  user.Id,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to close wallet")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "wallet closed successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) GetWalletStatusHistory(w http.ResponseWriter, r *http.Request) {
+	walletId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.WalletStatusHistory(walletId)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get wallet status history")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "wallet status history retrieved successfully",
+		Data:    data,
+	})
+}
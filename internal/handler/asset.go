@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/validations"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *admin) ListAssets(w http.ResponseWriter, r *http.Request) {
+	data, err := h.asset.List()
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to list assets")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "assets retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) CreateAsset(w http.ResponseWriter, r *http.Request) {
+	input, err := validations.ValidateAssetInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.asset.Create(input)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to create asset")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
+		Success: true,
+		Message: "asset created successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) GetAsset(w http.ResponseWriter, r *http.Request) {
+	data, err := h.asset.Get(strings.ToUpper(chi.URLParam(r, "asset")))
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get asset")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "asset retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) UpdateAsset(w http.ResponseWriter, r *http.Request) {
+	input, err := validations.ValidateAssetUpdateInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.asset.Update(strings.ToUpper(chi.URLParam(r, "asset")), input)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to update asset")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "asset updated successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) DeactivateAsset(w http.ResponseWriter, r *http.Request) {
+	data, err := h.asset.Deactivate(strings.ToUpper(chi.URLParam(r, "asset")))
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to deactivate asset")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "asset deactivated successfully",
+		Data:    data,
+	})
+}
@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/AdityaTote/wallet-service/internal/config"
 	"github.com/AdityaTote/wallet-service/internal/lib/utils"
 	"github.com/AdityaTote/wallet-service/internal/models"
 	"github.com/AdityaTote/wallet-service/internal/service"
@@ -13,10 +18,18 @@ import (
 type AuthHandler interface {
 	Signup(w http.ResponseWriter, r *http.Request)
 	Signin(w http.ResponseWriter, r *http.Request)
+	Refresh(w http.ResponseWriter, r *http.Request)
+	Logout(w http.ResponseWriter, r *http.Request)
+	LogoutAll(w http.ResponseWriter, r *http.Request)
+	ChangePassword(w http.ResponseWriter, r *http.Request)
 }
 
+// refreshCookiePath limits the refresh cookie to the auth routes that read it
+const refreshCookiePath = "/api/auth"
+
 type auth struct {
 	svc service.AuthService
+	cfg *config.Config
 	log zerolog.Logger
 }
 
@@ -38,11 +51,39 @@ func (h *auth) Signup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// signin accepts any stored credentials; the rules apply to new ones
+	if err := validations.ValidateUsername(input.Username); err != nil {
+		utils.JSONWriter(w, http.StatusUnprocessableEntity, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err := validations.ValidatePassword(input.Password, input.Username, h.cfg); err != nil {
+		utils.JSONWriter(w, http.StatusUnprocessableEntity, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
 	user, err := h.svc.Signup(models.UserParams{
 		Username: input.Username,
 		Password: input.Password,
+		Referrer: input.Referrer,
+		UserAgent: r.UserAgent(),
+		IPAddress: utils.ClientIP(r, h.cfg.TrustedProxyPrefixes),
 	})
 	if err != nil {
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONWriter(w, appErr.StatusCode, models.JSONResponse{
+				Success: false,
+				Message: appErr.Message,
+			})
+			return
+		}
+
 		utils.JSONWriter(w, http.StatusInternalServerError, models.JSONResponse{
 			Success: false,
 			Message: err.Error(),
@@ -50,7 +91,14 @@ func (h *auth) Signup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	utils.SetCookie(w, "ssid", user.AccessToken, true)
+	if input.Cookie {
+		csrfToken, err := h.setSessionCookies(w, user.AccessToken, user.RefreshToken)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		user.AccessToken, user.RefreshToken, user.CSRFToken = "", "", csrfToken
+	}
 
 	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
 		Success: true,
@@ -59,7 +107,6 @@ func (h *auth) Signup(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-
 func (h *auth) Signin(w http.ResponseWriter, r *http.Request) {
 	input, err := validations.ValidateAuthInput(r, h.log)
 	if err != nil {
@@ -81,20 +128,241 @@ func (h *auth) Signin(w http.ResponseWriter, r *http.Request) {
 	user, err := h.svc.Signin(models.UserParams{
 		Username: input.Username,
 		Password: input.Password,
+		UserAgent: r.UserAgent(),
+		IPAddress: utils.ClientIP(r, h.cfg.TrustedProxyPrefixes),
 	})
 	if err != nil {
-		utils.JSONWriter(w, http.StatusInternalServerError, models.JSONResponse{
-			Success: false,
-			Message: err.Error(),
-		})
+		var retryErr *models.RetryAfterError
+		if errors.As(err, &retryErr) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+		}
+		h.writeError(w, err)
 		return
 	}
 
-	utils.SetCookie(w, "ssid", user.AccessToken, true)
+	if input.Cookie {
+		csrfToken, err := h.setSessionCookies(w, user.AccessToken, user.RefreshToken)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		user.AccessToken, user.RefreshToken, user.CSRFToken = "", "", csrfToken
+	}
 
 	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
 		Success: true,
 		Message: "User logged in successfully",
 		Data:    user,
 	})
+}
+
+func (h *auth) Refresh(w http.ResponseWriter, r *http.Request) {
+	// a browser session sends its refresh token as a cookie, which a
+	// cross-site page could make it send too
+	if refreshToken, _ := utils.GetCookieValue(r, utils.RefreshCookie); refreshToken != "" {
+		h.refreshCookie(w, r, refreshToken)
+		return
+	}
+
+	input, err := validations.ValidateRefreshInput(r, h.log)
+	if err != nil {
+		switch err {
+		case models.ErrInvalidBody:
+			utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		default:
+			utils.JSONWriter(w, http.StatusUnprocessableEntity, models.JSONResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	tokens, err := h.svc.Refresh(models.RefreshParams{
+		RefreshToken: input.RefreshToken,
+		UserAgent: r.UserAgent(),
+		IPAddress: utils.ClientIP(r, h.cfg.TrustedProxyPrefixes),
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "Session refreshed successfully",
+		Data:    tokens,
+	})
+}
+
+func (h *auth) refreshCookie(w http.ResponseWriter, r *http.Request, refreshToken string) {
+	if !utils.CheckCSRF(r) {
+		h.writeError(w, models.ErrInvalidCSRFToken)
+		return
+	}
+
+	tokens, err := h.svc.Refresh(models.RefreshParams{
+		RefreshToken: refreshToken,
+		UserAgent: r.UserAgent(),
+		IPAddress: utils.ClientIP(r, h.cfg.TrustedProxyPrefixes),
+	})
+	if err != nil {
+		h.clearSessionCookies(w)
+		h.writeError(w, err)
+		return
+	}
+
+	csrfToken, err := h.setSessionCookies(w, tokens.AccessToken, tokens.RefreshToken)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	tokens.AccessToken, tokens.RefreshToken, tokens.CSRFToken = "", "", csrfToken
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "Session refreshed successfully",
+		Data:    tokens,
+	})
+}
+
+func (h *auth) Logout(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value("user").(models.User)
+
+	if err := h.svc.Logout(user.SessionId); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.clearSessionCookies(w)
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "User logged out successfully",
+	})
+}
+
+func (h *auth) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value("user").(models.User)
+
+	data, err := h.svc.LogoutAll(user.Id)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.clearSessionCookies(w)
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "User logged out of all sessions",
+		Data:    data,
+	})
+}
+
+func (h *auth) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value("user").(models.User)
+
+	input, err := validations.ValidatePasswordChangeInput(r, h.log)
+	if err != nil {
+		switch err {
+		case models.ErrInvalidBody:
+			utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		default:
+			utils.JSONWriter(w, http.StatusUnprocessableEntity, models.JSONResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	// the password policy is checked by the service, which loads the username
+	tokens, err := h.svc.ChangePassword(user.Id, models.PasswordChangeParams{
+		CurrentPassword: input.CurrentPassword,
+		NewPassword: input.NewPassword,
+		UserAgent: r.UserAgent(),
+		IPAddress: utils.ClientIP(r, h.cfg.TrustedProxyPrefixes),
+	})
+	if err != nil {
+		var retryErr *models.RetryAfterError
+		if errors.As(err, &retryErr) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+		}
+		h.writeError(w, err)
+		return
+	}
+
+	// the old session is gone, so a cookie client gets the new one as cookies
+	if input.Cookie || user.CookieAuth {
+		csrfToken, err := h.setSessionCookies(w, tokens.AccessToken, tokens.RefreshToken)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		tokens.AccessToken, tokens.RefreshToken, tokens.CSRFToken = "", "", csrfToken
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "Password changed successfully",
+		Data:    tokens,
+	})
+}
+
+func (h *auth) writeError(w http.ResponseWriter, err error) {
+	var appErr *models.AppError
+	if errors.As(err, &appErr) {
+		utils.JSONWriter(w, appErr.StatusCode, models.JSONResponse{
+			Success: false,
+			Message: appErr.Message,
+		})
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusInternalServerError, models.JSONResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
+
+// setSessionCookies stores the tokens of a cookie-based session together
+// with a new CSRF token, which is returned so the client can echo it in the
+// X-CSRF-Token header.
+func (h *auth) setSessionCookies(w http.ResponseWriter, accessToken, refreshToken string) (string, error) {
+	csrfToken, err := utils.GenerateCSRFToken()
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to generate csrf token")
+		return "", models.NewAppError(err, "failed to set session cookies", 500)
+	}
+
+	utils.SetCookie(w, utils.SessionCookie, accessToken, h.cookieOptions("/", h.cfg.AccessTokenTTL, true))
+	utils.SetCookie(w, utils.RefreshCookie, refreshToken, h.cookieOptions(refreshCookiePath, h.cfg.RefreshTokenTTL, true))
+	// the client has to read this one to send it back in the header
+	utils.SetCookie(w, utils.CSRFCookie, csrfToken, h.cookieOptions("/", h.cfg.RefreshTokenTTL, false))
+
+	return csrfToken, nil
+}
+
+func (h *auth) clearSessionCookies(w http.ResponseWriter) {
+	utils.ClearCookie(w, utils.SessionCookie, h.cookieOptions("/", 0, true))
+	utils.ClearCookie(w, utils.RefreshCookie, h.cookieOptions(refreshCookiePath, 0, true))
+	utils.ClearCookie(w, utils.CSRFCookie, h.cookieOptions("/", 0, false))
+}
+
+func (h *auth) cookieOptions(path string, maxAge time.Duration, httpOnly bool) utils.CookieOptions {
+	return utils.CookieOptions{
+		Path: path,
+		Domain: h.cfg.CookieDomain,
+		MaxAge: int(maxAge.Seconds()),
+		HttpOnly: httpOnly,
+		Secure: h.cfg.CookieSecure,
+		SameSite: h.cfg.CookieSameSiteMode,
+	}
 }
\ No newline at end of file
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/validations"
+)
+
+func (h *admin) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	data, err := h.campaign.List()
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to list campaigns")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "campaigns retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	input, err := validations.ValidateCampaignInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.campaign.Create(input)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to create campaign")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
+		Success: true,
+		Message: "campaign created successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.campaign.Get(campaignId)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get campaign")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "campaign retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) UpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateCampaignUpdateInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.campaign.Update(campaignId, input)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to update campaign")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "campaign updated successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) EndCampaign(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.campaign.End(campaignId)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to end campaign")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "campaign ended successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) GrantBonus(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateCampaignGrantInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.wallet.Bonus(&models.BonusServiceParams{
+		CampaignId: campaignId,
+		CampaignGrantRequest: *input,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to grant bonus")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
+		Success: true,
+		Message: "bonus granted successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) writeError(w http.ResponseWriter, err error) {
+	var appErr *models.AppError
+	if errors.As(err, &appErr) {
+		utils.JSONWriter(w, appErr.StatusCode, models.JSONResponse{
+			Success: false,
+			Message: appErr.Message,
+		})
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusInternalServerError, models.JSONResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/validations"
+)
+
+func (h *wallet) Quote(w http.ResponseWriter, r *http.Request) {
+	urs, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+			Success: false,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	input, err := validations.ValidateQuoteInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.Quote(&models.QuoteServiceParams{
+		UserId: urs.Id,
+		QuoteRequest: *input,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to create quote")
+		h.writeConvertError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
+		Success: true,
+		Message: "quote created successfully",
+		Data:    data,
+	})
+}
+
+func (h *wallet) GetQuote(w http.ResponseWriter, r *http.Request) {
+	urs, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+			Success: false,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	quoteId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.GetQuote(urs.Id, quoteId)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get quote")
+		h.writeConvertError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "quote retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *wallet) Convert(w http.ResponseWriter, r *http.Request) {
+	urs, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+			Success: false,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	input, err := validations.ValidateConvertInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.Convert(&models.ConvertServiceParams{
+		UserId: urs.Id,
+		ConvertRequest: *input,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to convert")
+		h.writeConvertError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
+		Success: true,
+		Message: "conversion successful",
+		Data:    data,
+	})
+}
+
+func (h *wallet) writeConvertError(w http.ResponseWriter, err error) {
+	if writeLimitError(w, err) {
+		return
+	}
+
+	var appErr *models.AppError
+	if errors.As(err, &appErr) {
+		utils.JSONWriter(w, appErr.StatusCode, models.JSONResponse{
+			Success: false,
+			Message: appErr.Message,
+		})
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusInternalServerError, models.JSONResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/validations"
+)
+
+func (h *admin) ListFxRates(w http.ResponseWriter, r *http.Request) {
+	data, err := h.fx.ListRates()
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to list exchange rates")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "exchange rates retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) CreateFxRate(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value("user").(models.User)
+
+	input, err := validations.ValidateFxRateInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.fx.CreateRate(&models.FxRateServiceParams{
+		FxRateRequest: *input,
+		ActorId: user.Id,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to create exchange rate")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
+		Success: true,
+		Message: "exchange rate created successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) GetFxRate(w http.ResponseWriter, r *http.Request) {
+	rateId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.fx.GetRate(rateId)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get exchange rate")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "exchange rate retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) EndFxRate(w http.ResponseWriter, r *http.Request) {
+	rateId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.fx.EndRate(rateId)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to end exchange rate")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "exchange rate ended successfully",
+		Data:    data,
+	})
+}
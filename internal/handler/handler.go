@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"github.com/AdityaTote/wallet-service/internal/config"
 	"github.com/AdityaTote/wallet-service/internal/service"
 	"github.com/rs/zerolog"
 )
@@ -10,16 +11,20 @@ type Handlers interface {
 	Health() HealthHandler
 	Auth() AuthHandler
 	Wallet() WalletHandler
+	Admin() AdminHandler
+	Keys() KeysHandler
 }
 
 type handlers struct {
 	svc service.Services
+	cfg *config.Config
 	log zerolog.Logger
 }
 
-func New(svc service.Services, log zerolog.Logger) Handlers {
+func New(svc service.Services, cfg *config.Config, log zerolog.Logger) Handlers {
 	return &handlers{
 		svc: svc,
+		cfg: cfg,
 		log: log,
 	}
 }
@@ -33,6 +38,7 @@ func (h *handlers) Health() HealthHandler {
 func (h *handlers) Auth() AuthHandler {
 	return &auth{
 		svc: h.svc.Auth(),
+		cfg: h.cfg,
 		log: h.log,
 	}
 }
@@ -42,4 +48,23 @@ func (h *handlers) Wallet() WalletHandler {
 		svc: h.svc.Wallet(),
 		log: h.log,
 	}
+}
+
+func (h *handlers) Admin() AdminHandler {
+	return &admin{
+		svc: h.svc.Admin(),
+		reconcile: h.svc.Reconcile(),
+		campaign: h.svc.Campaign(),
+		wallet: h.svc.Wallet(),
+		limit: h.svc.Limit(),
+		asset: h.svc.Asset(),
+		fx: h.svc.Fx(),
+		log: h.log,
+	}
+}
+
+func (h *handlers) Keys() KeysHandler {
+	return &keys{
+		cfg: h.cfg,
+	}
 }
\ No newline at end of file
@@ -10,6 +10,7 @@ import (
 
 type HealthHandler interface {
 	CheckHealth(w http.ResponseWriter, r *http.Request)
+	CheckReady(w http.ResponseWriter, r *http.Request)
 }
 
 type health struct {
@@ -23,4 +24,24 @@ func (h *health) CheckHealth(w http.ResponseWriter, r *http.Request) {
 		Message: "service is healthy",
 		Data: data,
 	})
+}
+
+// CheckReady answers the readiness probe: 200 while the instance takes
+// traffic, 503 once it is draining or has lost its database.
+func (h *health) CheckReady(w http.ResponseWriter, r *http.Request) {
+	data := h.svc.CheckReady()
+	if data.Status != "ready" {
+		utils.JSONWriter(w, http.StatusServiceUnavailable, models.JSONResponse{
+			Success: false,
+			Message: "service is not ready",
+			Data: data,
+		})
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "service is ready",
+		Data: data,
+	})
 }
\ No newline at end of file
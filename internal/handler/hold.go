@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/validations"
+)
+
+func (h *wallet) Authorize(w http.ResponseWriter, r *http.Request) {
+	urs, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+			Success: false,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	input, err := validations.ValidateHoldInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.Authorize(&models.HoldServiceParams{
+		UserId: urs.Id,
+		HoldRequest: *input,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to authorize hold")
+		h.writeHoldError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
+		Success: true,
+		Message: "hold authorized successfully",
+		Data:    data,
+	})
+}
+
+func (h *wallet) Capture(w http.ResponseWriter, r *http.Request) {
+	urs, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+			Success: false,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	holdId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateCaptureInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.Capture(&models.CaptureServiceParams{
+		UserId: urs.Id,
+		HoldId: holdId,
+		CaptureRequest: *input,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to capture hold")
+		h.writeHoldError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
+		Success: true,
+		Message: "hold captured successfully",
+		Data:    data,
+	})
+}
+
+func (h *wallet) Void(w http.ResponseWriter, r *http.Request) {
+	urs, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+			Success: false,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	holdId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.Void(urs.Id, holdId)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to void hold")
+		h.writeHoldError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "hold voided successfully",
+		Data:    data,
+	})
+}
+
+func (h *wallet) GetHold(w http.ResponseWriter, r *http.Request) {
+	urs, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+			Success: false,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	holdId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.GetHold(urs.Id, holdId)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get hold")
+		h.writeHoldError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "hold retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *wallet) writeHoldError(w http.ResponseWriter, err error) {
+	if writeLimitError(w, err) {
+		return
+	}
+
+	var appErr *models.AppError
+	if errors.As(err, &appErr) {
+		utils.JSONWriter(w, appErr.StatusCode, models.JSONResponse{
+			Success: false,
+			Message: appErr.Message,
+		})
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusInternalServerError, models.JSONResponse{
+		Success: false,
+		Message: err.Error(),
+	})
+}
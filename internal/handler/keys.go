@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+)
+
+type KeysHandler interface {
+	JWKS(w http.ResponseWriter, r *http.Request)
+}
+
+type keys struct {
+	cfg *config.Config
+}
+
+// JWKS publishes the public keys that verify access tokens. It is a plain
+// JWK Set rather than the usual response envelope, so JWT libraries can
+// fetch it directly.
+func (h *keys) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+
+	_ = json.NewEncoder(w).Encode(h.cfg.TokenKeys.JWKS())
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/validations"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *admin) GetUserLimits(w http.ResponseWriter, r *http.Request) {
+	userId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.limit.Get(userId)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get user limits")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "limits retrieved successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) SetUserLimitTier(w http.ResponseWriter, r *http.Request) {
+	userId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateLimitTierInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.limit.SetTier(userId, input.Tier)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to set user limit tier")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "limit tier updated successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) SetUserTransactionLimit(w http.ResponseWriter, r *http.Request) {
+	userId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateTransactionLimitInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.limit.SetTransactionLimit(userId, input)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to set user transaction limit")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "transaction limit set successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) DeleteUserTransactionLimit(w http.ResponseWriter, r *http.Request) {
+	userId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	txnType, err := validations.ValidateTransactionTypeParam(r, "type")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.limit.DeleteTransactionLimit(userId, strings.ToUpper(chi.URLParam(r, "asset")), txnType)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to delete user transaction limit")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "transaction limit removed successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) SetUserBalanceLimit(w http.ResponseWriter, r *http.Request) {
+	userId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateBalanceLimitInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.limit.SetBalanceLimit(userId, input)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to set user balance limit")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "balance limit set successfully",
+		Data:    data,
+	})
+}
+
+func (h *admin) DeleteUserBalanceLimit(w http.ResponseWriter, r *http.Request) {
+	userId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.limit.DeleteBalanceLimit(userId, strings.ToUpper(chi.URLParam(r, "asset")))
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to delete user balance limit")
+		h.writeError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "balance limit removed successfully",
+		Data:    data,
+	})
+}
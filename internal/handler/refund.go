@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/validations"
+)
+
+// Refund refunds a user's spend on an operator's authority.
+func (h *wallet) Refund(w http.ResponseWriter, r *http.Request) {
+	urs, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+			Success: false,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	transactionId, err := validations.ValidateIdParam(r, "id")
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	input, err := validations.ValidateRefundInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.Refund(&models.RefundServiceParams{
+		ActorId: urs.Id,
+		TransactionId: transactionId,
+		RefundRequest: *input,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to refund transaction")
+		h.writeHoldError(w, err)
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
+		Success: true,
+		Message: "transaction refunded successfully",
+		Data:    data,
+	})
+}
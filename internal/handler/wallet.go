@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/AdityaTote/wallet-service/internal/lib/utils"
 	"github.com/AdityaTote/wallet-service/internal/models"
@@ -14,7 +15,17 @@ import (
 type WalletHandler interface {
 	TopUp(w http.ResponseWriter, r *http.Request)
 	Spend(w http.ResponseWriter, r *http.Request)
+	Transfer(w http.ResponseWriter, r *http.Request)
 	GetBalance(w http.ResponseWriter, r *http.Request)
+	GetTransactions(w http.ResponseWriter, r *http.Request)
+	Authorize(w http.ResponseWriter, r *http.Request)
+	Capture(w http.ResponseWriter, r *http.Request)
+	Void(w http.ResponseWriter, r *http.Request)
+	GetHold(w http.ResponseWriter, r *http.Request)
+	Refund(w http.ResponseWriter, r *http.Request)
+	Quote(w http.ResponseWriter, r *http.Request)
+	GetQuote(w http.ResponseWriter, r *http.Request)
+	Convert(w http.ResponseWriter, r *http.Request)
 }
 
 type wallet struct {
@@ -43,15 +54,19 @@ func (h *wallet) TopUp(w http.ResponseWriter, r *http.Request) {
 
 	data, err := h.svc.TopUp(&models.WalletServiceParams{
 		UserId: urs.Id,
-		WalletId: urs.WalletId,
 		WalletRequest: models.WalletRequest{
 			TxnId: input.TxnId,
 			Amount: input.Amount,
+			Asset: input.Asset,
 		},
 	})
 	if err != nil {
 		h.log.Error().Err(err).Msg("failed to top-up wallet")
 		
+		if writeLimitError(w, err) {
+			return
+		}
+
 		var appErr *models.AppError
 		if errors.As(err, &appErr) {
 			utils.JSONWriter(w, appErr.StatusCode, models.JSONResponse{
@@ -76,7 +91,7 @@ func (h *wallet) TopUp(w http.ResponseWriter, r *http.Request) {
 	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
 		Success: true,
 		Message: message,
-		Data:    data.Balance,
+		Data:    data.WalletBalance,
 	})
 }
 
@@ -101,15 +116,19 @@ func (h *wallet) Spend(w http.ResponseWriter, r *http.Request) {
 
 	data, err := h.svc.Spend(&models.WalletServiceParams{
 		UserId: urs.Id,
-		WalletId: urs.WalletId,
 		WalletRequest: models.WalletRequest{
 			TxnId: input.TxnId,
 			Amount: input.Amount,
+			Asset: input.Asset,
 		},
 	})
 	if err != nil {
 		h.log.Error().Err(err).Msg("failed to spend wallet")
 		
+		if writeLimitError(w, err) {
+			return
+		}
+
 		var appErr *models.AppError
 		if errors.As(err, &appErr) {
 			utils.JSONWriter(w, appErr.StatusCode, models.JSONResponse{
@@ -134,7 +153,65 @@ func (h *wallet) Spend(w http.ResponseWriter, r *http.Request) {
 	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
 		Success: true,
 		Message: message,
-		Data:    data.Balance,
+		Data:    data.WalletBalance,
+	})
+}
+
+func (h *wallet) Transfer(w http.ResponseWriter, r *http.Request) {
+	urs, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+			Success: false,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	input, err := validations.ValidateTransferInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.Transfer(&models.TransferServiceParams{
+		UserId: urs.Id,
+		TransferRequest: *input,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to transfer from wallet")
+
+		if writeLimitError(w, err) {
+			return
+		}
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONWriter(w, appErr.StatusCode, models.JSONResponse{
+				Success: false,
+				Message: appErr.Message,
+			})
+			return
+		}
+
+		utils.JSONWriter(w, http.StatusInternalServerError, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	message := "wallet transfer successful"
+	if data.Message != "" {
+		message = data.Message
+	}
+
+	utils.JSONWriter(w, http.StatusCreated, models.JSONResponse{
+		Success: true,
+		Message: message,
+		Data:    data.WalletBalance,
 	})
 }
 
@@ -148,7 +225,7 @@ func (h *wallet) GetBalance(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	data, err := h.svc.Balance(urs.Id, urs.WalletId)
+	data, err := h.svc.Balance(urs.Id, strings.ToUpper(r.URL.Query().Get("asset")))
 	if err != nil {
 		h.log.Error().Err(err).Msg("failed to get wallet balance")
 
@@ -173,4 +250,70 @@ func (h *wallet) GetBalance(w http.ResponseWriter, r *http.Request) {
 		Message: "wallet balance retrieved successfully",
 		Data:    data,
 	})
+}
+
+func (h *wallet) GetTransactions(w http.ResponseWriter, r *http.Request) {
+	urs, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+			Success: false,
+			Message: "unauthorized",
+		})
+		return
+	}
+
+	input, err := validations.ValidateTransactionHistoryInput(r, h.log)
+	if err != nil {
+		utils.JSONWriter(w, http.StatusBadRequest, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	data, err := h.svc.History(&models.TransactionHistoryParams{
+		UserId: urs.Id,
+		TransactionHistoryRequest: *input,
+	})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get wallet transactions")
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			utils.JSONWriter(w, appErr.StatusCode, models.JSONResponse{
+				Success: false,
+				Message: appErr.Message,
+			})
+			return
+		}
+
+		utils.JSONWriter(w, http.StatusInternalServerError, models.JSONResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	utils.JSONWriter(w, http.StatusOK, models.JSONResponse{
+		Success: true,
+		Message: "wallet transactions retrieved successfully",
+		Data:    data,
+	})
+}
+
+// writeLimitError answers a transaction that broke one of the user's limits
+// with the limit in the response data. It reports whether err was such a
+// breach.
+func writeLimitError(w http.ResponseWriter, err error) bool {
+	var limitErr *models.LimitExceededError
+	if !errors.As(err, &limitErr) {
+		return false
+	}
+
+	utils.JSONWriter(w, limitErr.Err.StatusCode, models.JSONResponse{
+		Success: false,
+		Message: limitErr.Err.Message,
+		Data:    limitErr.Limit,
+	})
+	return true
 }
\ No newline at end of file
@@ -4,6 +4,27 @@ import (
 	"net/http"
 )
 
+const (
+	// SessionCookie holds the access token of a cookie-based session
+	SessionCookie = "ssid"
+	// RefreshCookie holds the refresh token of a cookie-based session
+	RefreshCookie = "srt"
+	// CSRFCookie holds the token that state-changing requests authenticated
+	// by cookie must echo in CSRFHeader
+	CSRFCookie = "csrf_token"
+	CSRFHeader = "X-CSRF-Token"
+)
+
+// CookieOptions are the attributes of a cookie set by SetCookie.
+type CookieOptions struct {
+	Path     string
+	Domain   string
+	MaxAge   int
+	HttpOnly bool
+	Secure   bool
+	SameSite http.SameSite
+}
+
 func GetCookieValue(r *http.Request, name string) (string, error) {
 	c, err := r.Cookie(name)
 	if err != nil {
@@ -12,14 +33,22 @@ func GetCookieValue(r *http.Request, name string) (string, error) {
 	return c.Value, nil
 }
 
-func SetCookie(w http.ResponseWriter, name, value string, httpOnly bool) {
+func SetCookie(w http.ResponseWriter, name, value string, opts CookieOptions) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     name,
 		Value:    value,
-		Path:     "/",
-		MaxAge:   86400 * 7,
-		HttpOnly: httpOnly,
-		Secure:   false,
-		SameSite: http.SameSiteLaxMode,
+		Path:     opts.Path,
+		Domain:   opts.Domain,
+		MaxAge:   opts.MaxAge,
+		HttpOnly: opts.HttpOnly,
+		Secure:   opts.Secure,
+		SameSite: opts.SameSite,
 	})
-}
\ No newline at end of file
+}
+
+// ClearCookie tells the client to drop a cookie set by SetCookie. Path and
+// Domain must match the ones the cookie was set with.
+func ClearCookie(w http.ResponseWriter, name string, opts CookieOptions) {
+	opts.MaxAge = -1
+	SetCookie(w, name, "", opts)
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor builds an opaque pagination cursor from the ledger seq of the
+// last row in a page, so the next page starts exactly after that row.
+func EncodeCursor(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(seq, 10)))
+}
+
+func DecodeCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	seq, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || seq <= 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return seq, nil
+}
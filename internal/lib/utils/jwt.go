@@ -10,39 +10,46 @@ import (
 
 type AccessClaims struct {
 	UserID string `json:"uid"`
-	WalletID uuid.UUID `json:"wid"`
+	// SessionID names the session the token was issued for, so revoking the
+	// session ends the token too
+	SessionID string `json:"sid"`
+	// Role is the user's role when the token was issued, for services that
+	// verify tokens against the JWKS. This service reads the role from the
+	// user row, so a role change applies from the next request.
+	Role string `json:"role"`
 	jwt.RegisteredClaims
 }
 
-var (
-	accessTTL  = 24 * time.Hour
-)
-
-func GenerateAccessToken(accessSecret []byte, userID uuid.UUID, walletId uuid.UUID) (string, error) {
+// GenerateAccessToken signs an access token with the key set's signing key.
+// Asymmetric tokens name their key in the kid header.
+func GenerateAccessToken(keys *KeySet, userID, sessionID uuid.UUID, role string, ttl time.Duration) (string, error) {
 	claims := AccessClaims{
 		UserID: userID.String(),
-		WalletID: walletId,
+		SessionID: sessionID.String(),
+		Role: role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(accessTTL)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "wallet-service",
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(accessSecret)
+	token := jwt.NewWithClaims(keys.signingMethod, claims)
+	if keys.signingKey == nil {
+		return token.SignedString(keys.secret)
+	}
+
+	token.Header["kid"] = keys.signingKID
+	return token.SignedString(keys.signingKey)
 }
 
-func ParseAccessToken(accessSecret []byte, tokenStr string) (*AccessClaims, error) {
+func ParseAccessToken(keys *KeySet, tokenStr string) (*AccessClaims, error) {
 	token, err := jwt.ParseWithClaims(
 		tokenStr,
 		&AccessClaims{},
-		func(t *jwt.Token) (interface{}, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.New("unexpected signing method")
-			}
-			return accessSecret, nil
-		},
+		keys.keyFor,
+		jwt.WithValidMethods([]string{"HS256", "RS256", "EdDSA"}),
+		jwt.WithIssuer("wallet-service"),
 	)
 
 	if err != nil || !token.Valid {
@@ -0,0 +1,216 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits is the smallest RSA key accepted for signing or verifying
+const minRSAKeyBits = 2048
+
+// KeySet holds the key access tokens are signed with and the public keys
+// they are verified against. Asymmetric keys are looked up by the token's
+// kid header, so tokens signed with a retired key stay valid while its
+// public key is still listed.
+type KeySet struct {
+	signingKID    string
+	signingKey    crypto.Signer
+	signingMethod jwt.SigningMethod
+	verification  map[string]verificationKey
+	// secret signs with HS256 when there is no signing key, and verifies
+	// HS256 tokens as long as it is set
+	secret []byte
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+// LoadKeySet builds a KeySet from PEM files. signingKeyFile holds an RSA or
+// Ed25519 private key in PKCS#8 (or PKCS#1 for RSA); verificationKeyFiles
+// maps the kid of each retired key to a PEM file with its public key. When
+// signingKeyFile is empty, tokens are signed with secret using HS256.
+func LoadKeySet(secret, signingKeyFile, signingKID string, verificationKeyFiles map[string]string) (*KeySet, error) {
+	keys := &KeySet{
+		verification: map[string]verificationKey{},
+	}
+	if secret != "" {
+		keys.secret = []byte(secret)
+	}
+
+	if signingKeyFile == "" {
+		if keys.secret == nil {
+			return nil, errors.New("either a signing key or a secret is required")
+		}
+		keys.signingMethod = jwt.SigningMethodHS256
+	} else {
+		if signingKID == "" {
+			return nil, errors.New("signing key has no key id")
+		}
+
+		signer, err := readPrivateKey(signingKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", signingKeyFile, err)
+		}
+
+		method, err := signingMethodFor(signer.Public())
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", signingKeyFile, err)
+		}
+
+		keys.signingKID = signingKID
+		keys.signingKey = signer
+		keys.signingMethod = method
+		keys.verification[signingKID] = verificationKey{method: method, key: signer.Public()}
+	}
+
+	for kid, file := range verificationKeyFiles {
+		if kid == keys.signingKID {
+			return nil, fmt.Errorf("verification key %s reuses the signing key id", kid)
+		}
+
+		key, err := readPublicKey(file)
+		if err != nil {
+			return nil, fmt.Errorf("verification key %s: %w", kid, err)
+		}
+
+		method, err := signingMethodFor(key)
+		if err != nil {
+			return nil, fmt.Errorf("verification key %s: %w", kid, err)
+		}
+
+		keys.verification[kid] = verificationKey{method: method, key: key}
+	}
+
+	return keys, nil
+}
+
+// JWKS returns the public verification keys in JWK Set form, sorted by kid.
+// The HS256 secret is never published.
+func (k *KeySet) JWKS() models.JWKSet {
+	kids := make([]string, 0, len(k.verification))
+	for kid := range k.verification {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	set := models.JWKSet{Keys: make([]models.JWK, 0, len(kids))}
+	for _, kid := range kids {
+		entry := k.verification[kid]
+		jwk := models.JWK{
+			Kid: kid,
+			Use: "sig",
+			Alg: entry.method.Alg(),
+		}
+
+		switch key := entry.key.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(key)
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+// keyFor returns the key that verifies token, rejecting tokens whose alg
+// does not belong to the key named by their kid.
+func (k *KeySet) keyFor(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if k.secret == nil || kid != "" {
+			return nil, errors.New("unexpected signing method")
+		}
+		return k.secret, nil
+	}
+
+	entry, ok := k.verification[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != entry.method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return entry.key, nil
+}
+
+func signingMethodFor(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key is shorter than %d bits", minRSAKeyBits)
+		}
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, want RSA or Ed25519", key)
+	}
+}
+
+func readPrivateKey(file string) (crypto.Signer, error) {
+	block, err := readPEM(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return signer, nil
+}
+
+func readPublicKey(file string) (crypto.PublicKey, error) {
+	block, err := readPEM(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+func readPEM(file string) (*pem.Block, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	return block, nil
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ClientIP returns the address the request came from, without the port.
+// When the connection comes from a trusted proxy, it is the last address in
+// X-Forwarded-For that is not a trusted proxy itself: proxies append the
+// address they received from, so anything left of that may be forged by the
+// client.
+func ClientIP(r *http.Request, trusted []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !isTrustedProxy(host, trusted) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if _, err := netip.ParseAddr(hop); err != nil {
+			// a malformed hop was not written by a trusted proxy
+			return host
+		}
+		if !isTrustedProxy(hop, trusted) {
+			return hop
+		}
+		host = hop
+	}
+	return host
+}
+
+func isTrustedProxy(host string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckCSRF reports whether the request echoes the value of its CSRF cookie
+// in the CSRF header. A cross-site page can make the browser send the cookie
+// but cannot read it to set the header.
+func CheckCSRF(r *http.Request) bool {
+	token, err := GetCookieValue(r, CSRFCookie)
+	if err != nil || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(r.Header.Get(CSRFHeader))) == 1
+}
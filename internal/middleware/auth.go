@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/AdityaTote/wallet-service/internal/lib/utils"
 	"github.com/AdityaTote/wallet-service/internal/models"
@@ -29,18 +30,16 @@ func NewAuthMiddleware(server *server.Server, repo *repository.Repository, log z
 
 func (m *AuthMiddleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token, _ := utils.GetCookieValue(r, "ssid")
-
-		if token == "" {
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
-					Success: false,
-					Message: "unauthorized",
-				})
-				return
-			}
+		// the Authorization header wins over the session cookie, so only
+		// requests that rely on the cookie need a CSRF token
+		var token string
+		cookieAuth := false
 
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			token, _ = utils.GetCookieValue(r, utils.SessionCookie)
+			cookieAuth = true
+		} else {
 			// Extract token from "Bearer <token>"
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || parts[0] != "Bearer" {
@@ -63,7 +62,7 @@ func (m *AuthMiddleware) Middleware(next http.Handler) http.Handler {
 		}
 
 		// verify access token
-		user, err := utils.ParseAccessToken([]byte(m.server.Config.JWTSecret), token)
+		user, err := utils.ParseAccessToken(m.server.Config.TokenKeys, token)
 		if err != nil {
 			utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
 				Success: false,
@@ -97,10 +96,20 @@ func (m *AuthMiddleware) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// check if user has a wallet
-		wallet, err := query.GetWalletByOwner(r.Context(), u.ID)
+		// check the session the token was issued for is still open
+		sessionID, err := uuid.Parse(user.SessionID)
 		if err != nil {
-			m.log.Error().Err(err).Msg("wallet not found for user")
+			m.log.Debug().Err(err).Msg("invalid session ID in token")
+			utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
+				Success: false,
+				Message: "unauthorized",
+			})
+			return
+		}
+
+		session, err := query.GetSessionById(r.Context(), sessionID)
+		if err != nil || session.UserID != u.ID || session.RevokedAt.Valid || !session.ExpiresAt.After(time.Now()) {
+			m.log.Debug().Msg("session revoked or expired")
 			utils.JSONWriter(w, http.StatusUnauthorized, models.JSONResponse{
 				Success: false,
 				Message: "unauthorized",
@@ -111,7 +120,9 @@ func (m *AuthMiddleware) Middleware(next http.Handler) http.Handler {
 		// add user info to context
 		ctx := context.WithValue(r.Context(), "user", models.User{
 			Id: u.ID,
-			WalletId: wallet.ID,
+			SessionId: session.ID,
+			Role: models.Role(u.Role),
+			CookieAuth: cookieAuth,
 		})
 
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -2,4 +2,5 @@ package middleware
 
 type Middlewares struct {
 	Auth      *AuthMiddleware
+	RBAC      *RBACMiddleware
 }
\ No newline at end of file
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/rs/zerolog"
+)
+
+type RBACMiddleware struct {
+	log zerolog.Logger
+}
+
+func NewRBACMiddleware(log zerolog.Logger) *RBACMiddleware {
+	return &RBACMiddleware{
+		log: log,
+	}
+}
+
+// Require runs after AuthMiddleware and admits callers whose role grants
+// perm. Everyone else gets 403, including plain users probing admin routes.
+func (m *RBACMiddleware) Require(perm models.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := r.Context().Value("user").(models.User)
+			if !ok || !user.Role.Can(perm) {
+				m.log.Warn().
+					Str("path", r.URL.Path).
+					Str("role", string(user.Role)).
+					Str("permission", string(perm)).
+					Msg("rejected request without permission")
+				utils.JSONWriter(w, models.ErrForbidden.StatusCode, models.JSONResponse{
+					Success: false,
+					Message: models.ErrForbidden.Message,
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=USER SUPPORT ADMIN FINANCE"`
+}
+
+type AdminUserResponse struct {
+	Id uuid.UUID `json:"id"`
+	Username string `json:"username"`
+	Role string `json:"role"`
+	LimitTier string `json:"limit_tier"`
+	CreatedAt time.Time `json:"created_at"`
+	Wallets []AdminWalletResponse `json:"wallets"`
+}
+
+type AdminWalletResponse struct {
+	Id uuid.UUID `json:"id"`
+	OwnerId uuid.UUID `json:"owner_id"`
+	Asset string `json:"asset"`
+	Decimals int16 `json:"decimals"`
+	Balance int64 `json:"balance"`
+	FormattedBalance string `json:"formatted_balance"`
+	AvailableBalance int64 `json:"available_balance"`
+	FormattedAvailableBalance string `json:"formatted_available_balance"`
+	Status string `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WalletStatusClosed is the final wallet state. It is set only through the
+// close route, which sweeps the balance out.
+const WalletStatusClosed = "CLOSED"
+
+type WalletStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=ACTIVE FROZEN_DEBIT FROZEN_ALL"`
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type WalletCloseRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type WalletStatusParams struct {
+	WalletId uuid.UUID
+	Status string
+	Reason string
+	// ActorId is the operator making the change
+	ActorId uuid.UUID
+}
+
+type WalletStatusChangeResponse struct {
+	Id uuid.UUID `json:"id"`
+	WalletId uuid.UUID `json:"wallet_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus string `json:"to_status"`
+	Reason string `json:"reason"`
+	ChangedBy *uuid.UUID `json:"changed_by,omitempty"`
+	SweepTransactionId *uuid.UUID `json:"sweep_transaction_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WalletStatusResponse struct {
+	Wallet AdminWalletResponse `json:"wallet"`
+	Change WalletStatusChangeResponse `json:"change"`
+	// SweptAmount is the balance moved to the system wallet on closure
+	SweptAmount int64 `json:"swept_amount"`
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+	"testing/quick"
+)
+
+// decimalsOf maps any generated byte to a valid number of asset decimals.
+func decimalsOf(d uint8) int16 {
+	return int16(d % (MaxDecimals + 1))
+}
+
+func decodeAmount(t *testing.T, data string) (Amount, error) {
+	t.Helper()
+	var amount Amount
+	err := json.Unmarshal([]byte(data), &amount)
+	return amount, err
+}
+
+// bigFormat is FormatAmount done with arbitrary precision.
+func bigFormat(minor int64, decimals int16) string {
+	if decimals <= 0 {
+		return strconv.FormatInt(minor, 10)
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return new(big.Rat).SetFrac(big.NewInt(minor), scale).FloatString(int(decimals))
+}
+
+func TestFormatAmountRoundTrip(t *testing.T) {
+	property := func(n int64, d uint8) bool {
+		minor := n % (MaxAmount + 1)
+		if minor < 0 {
+			minor = -minor
+		}
+		decimals := decimalsOf(d)
+
+		amount, err := decodeAmount(t, strconv.Quote(FormatAmount(minor, decimals)))
+		if err != nil {
+			return false
+		}
+		got, err := amount.MinorUnits(decimals)
+		return err == nil && got == minor
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFormatAmountMatchesBigArithmetic(t *testing.T) {
+	property := func(minor int64, d uint8) bool {
+		decimals := decimalsOf(d)
+		return FormatAmount(minor, decimals) == bigFormat(minor, decimals)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+
+	// balances and sums at the ends of the BIGINT range
+	for _, minor := range []int64{math.MaxInt64, math.MinInt64, math.MinInt64 + 1, -1, 0, 1} {
+		for decimals := int16(0); decimals <= MaxDecimals; decimals++ {
+			if got, want := FormatAmount(minor, decimals), bigFormat(minor, decimals); got != want {
+				t.Errorf("FormatAmount(%d, %d) = %q, want %q", minor, decimals, got, want)
+			}
+		}
+	}
+}
+
+func TestMinorUnitsNearMaxAmount(t *testing.T) {
+	property := func(k uint16, d uint8) bool {
+		decimals := decimalsOf(d)
+
+		below, err := decodeAmount(t, strconv.Quote(FormatAmount(MaxAmount-int64(k), decimals)))
+		if err != nil {
+			return false
+		}
+		if got, err := below.MinorUnits(decimals); err != nil || got != MaxAmount-int64(k) {
+			return false
+		}
+
+		above, err := decodeAmount(t, strconv.Quote(FormatAmount(MaxAmount+1+int64(k), decimals)))
+		if err != nil {
+			return false
+		}
+		_, err = above.MinorUnits(decimals)
+		return err == ErrAmountOutOfRange
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// Scaling whole units up to the asset's decimals either gives the exact
+// product or is refused; it never wraps around.
+func TestMinorUnitsScalesExactly(t *testing.T) {
+	limit := big.NewInt(MaxAmount)
+
+	property := func(n int64, d uint8, p uint8) bool {
+		digits := n
+		if digits < 0 {
+			digits = -(digits + 1)
+		}
+		decimals := decimalsOf(d)
+		places := int(p) % (int(decimals) + 1)
+
+		amount := Amount{Digits: digits, Places: places, Decimal: true}
+		got, err := amount.MinorUnits(decimals)
+
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(int(decimals)-places)), nil)
+		want := new(big.Int).Mul(big.NewInt(digits), scale)
+		if want.Cmp(limit) > 0 {
+			return err == ErrAmountOutOfRange
+		}
+		return err == nil && got == want.Int64()
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMinorUnitsRejectsExtraPlaces(t *testing.T) {
+	property := func(n uint32, d uint8) bool {
+		decimals := decimalsOf(d)
+		if decimals == MaxDecimals {
+			return true
+		}
+		amount := Amount{Digits: int64(n), Places: int(decimals) + 1, Decimal: true}
+		_, err := amount.MinorUnits(decimals)
+		return err == ErrAmountPrecision
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// A JSON number is minor units, so it is only read for an asset without
+// decimals, where minor and whole units agree.
+func TestMinorUnitsRejectsNumbers(t *testing.T) {
+	property := func(n uint32, d uint8) bool {
+		decimals := decimalsOf(d)
+		amount, err := decodeAmount(t, strconv.FormatUint(uint64(n), 10))
+		if err != nil {
+			return false
+		}
+		got, err := amount.MinorUnits(decimals)
+		if decimals > 0 {
+			return err == ErrAmountNotDecimal
+		}
+		return err == nil && got == int64(n)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnmarshalAmountOutOfRange(t *testing.T) {
+	for _, data := range []string{
+		"9223372036854775808",
+		"-9223372036854775809",
+		"99999999999999999999999",
+		`"9223372036854775808"`,
+		`"92233720368547758.08"`,
+	} {
+		if _, err := decodeAmount(t, data); err != ErrAmountOutOfRange {
+			t.Errorf("%s: got %v, want ErrAmountOutOfRange", data, err)
+		}
+	}
+
+	property := func(n int64) bool {
+		amount, err := decodeAmount(t, strconv.FormatInt(n, 10))
+		return err == nil && !amount.Decimal && amount.Digits == n
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AssetRequest struct {
+	Code string `json:"code" validate:"required,alphanum,max=16"`
+	Name string `json:"name" validate:"required,max=100"`
+	// Decimals is how many digits of an amount sit after the decimal point
+	Decimals int16 `json:"decimals" validate:"gte=0,lte=18"`
+}
+
+// AssetUpdateRequest changes an asset. The code cannot change: clients and
+// stored idempotency fingerprints refer to assets by it.
+type AssetUpdateRequest struct {
+	Name *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Decimals *int16 `json:"decimals" validate:"omitempty,gte=0,lte=18"`
+	Status *string `json:"status" validate:"omitempty,oneof=ACTIVE INACTIVE"`
+}
+
+type AssetResponse struct {
+	Id uuid.UUID `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Decimals int16 `json:"decimals"`
+	// Status is ACTIVE, or INACTIVE once the asset stopped taking top-ups
+	Status string `json:"status"`
+	SystemWalletId uuid.UUID `json:"system_wallet_id"`
+	PromotionWalletId uuid.UUID `json:"promotion_wallet_id"`
+	// TreasuryWalletId balances the asset's side of every conversion
+	TreasuryWalletId uuid.UUID `json:"treasury_wallet_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
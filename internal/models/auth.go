@@ -4,12 +4,40 @@ import "github.com/google/uuid"
 
 type User struct {
 	Id uuid.UUID
-	WalletId uuid.UUID
+	SessionId uuid.UUID
+	// Role is read from the user row on every request
+	Role Role
+	// CookieAuth is set when the request was authenticated by the session
+	// cookie rather than the Authorization header
+	CookieAuth bool
 }
 
 type UserParams struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
+	// Referrer is the username of the user who referred this one, used by
+	// referral campaigns at signup only
+	Referrer string `json:"referrer" validate:"omitempty,max=255"`
+	// Cookie asks for the session in cookies instead of the response body
+	Cookie bool `json:"cookie"`
+	// UserAgent and IPAddress describe the client the session is opened for
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
+}
+
+type PasswordChangeParams struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+	// Cookie asks for the new session in cookies instead of the response body
+	Cookie bool `json:"cookie"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
+}
+
+type RefreshParams struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
 }
 
 type UserResponse struct {
@@ -17,5 +45,23 @@ type UserResponse struct {
 	Username string `json:"username"`
 	WalletId    *uuid.UUID `json:"wallet_id,omitempty"`
 	Balance     *int64     `json:"balance,omitempty"`
-	AccessToken string `json:"access_token"`
+	// AccessToken and RefreshToken are left out when the session is kept
+	// in cookies
+	AccessToken string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// ExpiresIn is the access token lifetime in seconds
+	ExpiresIn int64 `json:"expires_in"`
+	CSRFToken string `json:"csrf_token,omitempty"`
+}
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn int64 `json:"expires_in"`
+	CSRFToken string `json:"csrf_token,omitempty"`
+}
+
+type LogoutResponse struct {
+	// Revoked is the number of sessions ended
+	Revoked int64 `json:"revoked"`
 }
\ No newline at end of file
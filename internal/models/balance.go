@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BalanceMismatch struct {
+	WalletId uuid.UUID `json:"wallet_id"`
+	OwnerType string `json:"owner_type"`
+	AssetId uuid.UUID `json:"asset_id"`
+	CachedBalance int64 `json:"cached_balance"`
+	CheckpointBalance int64 `json:"checkpoint_balance"`
+	LedgerBalance int64 `json:"ledger_balance"`
+}
+
+type BalanceVerificationReport struct {
+	CheckedAt time.Time `json:"checked_at"`
+	WalletsChecked int `json:"wallets_checked"`
+	Consistent bool `json:"consistent"`
+	Mismatches []BalanceMismatch `json:"mismatches"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CampaignRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	Rule string `json:"rule" validate:"required,oneof=SIGNUP_BONUS FIRST_TOPUP_MATCH REFERRAL_REWARD"`
+	Asset string `json:"asset" validate:"omitempty,alphanum,max=16"`
+	// Amount is the fixed reward of signup and referral campaigns
+	Amount Amount `json:"amount" validate:"omitempty,gt=0,lte=9007199254740991"`
+	// MatchPercent is the share of the first top-up a match campaign credits
+	MatchPercent int32 `json:"match_percent" validate:"omitempty,gt=0,lte=100"`
+	MaxReward Amount `json:"max_reward" validate:"omitempty,gt=0,lte=9007199254740991"`
+	Budget Amount `json:"budget" validate:"required,gt=0,lte=9007199254740991"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt *time.Time `json:"ends_at"`
+}
+
+type CampaignUpdateRequest struct {
+	Name *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Status *string `json:"status" validate:"omitempty,oneof=ACTIVE PAUSED"`
+	Budget *Amount `json:"budget" validate:"omitempty,gt=0,lte=9007199254740991"`
+	EndsAt *time.Time `json:"ends_at"`
+}
+
+type CampaignGrantRequest struct {
+	TxnId uuid.UUID `json:"txn_id" validate:"required"`
+	UserId uuid.UUID `json:"user_id" validate:"required"`
+	// Amount overrides the campaign's fixed reward
+	Amount Amount `json:"amount" validate:"omitempty,gt=0,lte=9007199254740991"`
+}
+
+type BonusServiceParams struct {
+	CampaignGrantRequest
+	CampaignId uuid.UUID
+}
+
+type CampaignResponse struct {
+	Id uuid.UUID `json:"id"`
+	Name string `json:"name"`
+	Rule string `json:"rule"`
+	Asset string `json:"asset"`
+	Status string `json:"status"`
+	Amount int64 `json:"amount"`
+	FormattedAmount string `json:"formatted_amount"`
+	MatchPercent int32 `json:"match_percent"`
+	MaxReward *int64 `json:"max_reward,omitempty"`
+	FormattedMaxReward *string `json:"formatted_max_reward,omitempty"`
+	Budget int64 `json:"budget"`
+	FormattedBudget string `json:"formatted_budget"`
+	Spent int64 `json:"spent"`
+	FormattedSpent string `json:"formatted_spent"`
+	Remaining int64 `json:"remaining"`
+	FormattedRemaining string `json:"formatted_remaining"`
+	WalletId uuid.UUID `json:"wallet_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt *time.Time `json:"ends_at,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type BonusResponse struct {
+	TransactionId uuid.UUID `json:"transaction_id"`
+	CampaignId uuid.UUID `json:"campaign_id"`
+	UserId uuid.UUID `json:"user_id"`
+	Amount int64 `json:"amount"`
+	FormattedAmount string `json:"formatted_amount"`
+	Balance int64 `json:"balance"`
+	FormattedBalance string `json:"formatted_balance"`
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"errors"
 	"net/http"
+	"time"
 )
 
 var (
@@ -40,6 +41,66 @@ var (
 		Message:    "wallet not found",
 		StatusCode: http.StatusNotFound,
 	}
+	ErrWalletFrozen = &AppError{
+		Err:        errors.New("wallet is frozen"),
+		Message:    "wallet is frozen",
+		StatusCode: http.StatusLocked,
+	}
+	ErrWalletClosed = &AppError{
+		Err:        errors.New("wallet is closed"),
+		Message:    "wallet is closed",
+		StatusCode: http.StatusLocked,
+	}
+	ErrRecipientUnavailable = &AppError{
+		Err:        errors.New("recipient wallet cannot receive funds"),
+		Message:    "recipient wallet cannot receive funds",
+		StatusCode: http.StatusLocked,
+	}
+	ErrWalletStatusUnchanged = &AppError{
+		Err:        errors.New("wallet is already in that state"),
+		Message:    "wallet is already in that state",
+		StatusCode: http.StatusConflict,
+	}
+	ErrWalletHasActiveHolds = &AppError{
+		Err:        errors.New("wallet has active holds, void or capture them first"),
+		Message:    "wallet has active holds, void or capture them first",
+		StatusCode: http.StatusConflict,
+	}
+	ErrLimitExceeded = &AppError{
+		Err:        errors.New("transaction limit exceeded"),
+		Message:    "transaction limit exceeded",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrRecipientLimitExceeded = &AppError{
+		Err:        errors.New("recipient wallet cannot receive this amount"),
+		Message:    "recipient wallet cannot receive this amount",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrLimitNotFound = &AppError{
+		Err:        errors.New("limit override not found"),
+		Message:    "limit override not found",
+		StatusCode: http.StatusNotFound,
+	}
+	ErrInvalidAmount = &AppError{
+		Err:        errors.New("invalid amount"),
+		Message:    `amount must be a whole number of minor units or a decimal string such as "12.50"`,
+		StatusCode: http.StatusBadRequest,
+	}
+	ErrAmountPrecision = &AppError{
+		Err:        errors.New("amount has more decimal places than the asset allows"),
+		Message:    "amount has more decimal places than the asset allows",
+		StatusCode: http.StatusBadRequest,
+	}
+	ErrAmountNotDecimal = &AppError{
+		Err:        errors.New("amount must be a decimal string"),
+		Message:    `amount must be a decimal string such as "12.50" for an asset with decimal places`,
+		StatusCode: http.StatusBadRequest,
+	}
+	ErrAmountOutOfRange = &AppError{
+		Err:        errors.New("amount is out of range"),
+		Message:    "amount must be at most 9007199254740991 minor units",
+		StatusCode: http.StatusBadRequest,
+	}
 	ErrInsufficientBalance = &AppError{
 		Err:        errors.New("insufficient balance"),
 		Message:    "insufficient balance",
@@ -60,8 +121,243 @@ var (
 		Message:    "failed to retrieve balance",
 		StatusCode: http.StatusInternalServerError,
 	}
+	ErrBalanceMismatch = &AppError{
+		Err:        errors.New("cached balance does not match the ledger"),
+		Message:    "cached balance does not match the ledger",
+		StatusCode: http.StatusInternalServerError,
+	}
+	ErrAssetNotFound = &AppError{
+		Err:        errors.New("asset not found"),
+		Message:    "asset not found",
+		StatusCode: http.StatusNotFound,
+	}
+	ErrAssetExists = &AppError{
+		Err:        errors.New("asset with this code already exists"),
+		Message:    "asset with this code already exists",
+		StatusCode: http.StatusConflict,
+	}
+	ErrAssetInactive = &AppError{
+		Err:        errors.New("asset is inactive"),
+		Message:    "asset is inactive",
+		StatusCode: http.StatusLocked,
+	}
+	ErrAssetDecimalsInUse = &AppError{
+		Err:        errors.New("asset decimals cannot change once it has ledger entries"),
+		Message:    "asset decimals cannot change once it has ledger entries",
+		StatusCode: http.StatusConflict,
+	}
+	ErrInvalidRate = &AppError{
+		Err:        errors.New("rate must be a positive decimal string with at most 18 decimal places"),
+		Message:    "rate must be a positive decimal string with at most 18 decimal places",
+		StatusCode: http.StatusBadRequest,
+	}
+	ErrInvalidRateWindow = &AppError{
+		Err:        errors.New("valid_until must be after valid_from"),
+		Message:    "valid_until must be after valid_from",
+		StatusCode: http.StatusBadRequest,
+	}
+	ErrSameAssetConversion = &AppError{
+		Err:        errors.New("from_asset and to_asset must be different assets"),
+		Message:    "from_asset and to_asset must be different assets",
+		StatusCode: http.StatusBadRequest,
+	}
+	ErrFxRateNotFound = &AppError{
+		Err:        errors.New("exchange rate not found"),
+		Message:    "exchange rate not found",
+		StatusCode: http.StatusNotFound,
+	}
+	ErrFxRateEnded = &AppError{
+		Err:        errors.New("exchange rate has already ended"),
+		Message:    "exchange rate has already ended",
+		StatusCode: http.StatusConflict,
+	}
+	ErrNoFxRate = &AppError{
+		Err:        errors.New("no exchange rate is in effect for this asset pair"),
+		Message:    "no exchange rate is in effect for this asset pair",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrConversionTooSmall = &AppError{
+		Err:        errors.New("amount converts to less than one minor unit of to_asset"),
+		Message:    "amount converts to less than one minor unit of to_asset",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrQuoteNotFound = &AppError{
+		Err:        errors.New("quote not found"),
+		Message:    "quote not found",
+		StatusCode: http.StatusNotFound,
+	}
+	ErrQuoteUsed = &AppError{
+		Err:        errors.New("quote has already been used"),
+		Message:    "quote has already been used",
+		StatusCode: http.StatusConflict,
+	}
+	ErrQuoteExpired = &AppError{
+		Err:        errors.New("quote has expired"),
+		Message:    "quote has expired",
+		StatusCode: http.StatusConflict,
+	}
+	ErrRecipientNotFound = &AppError{
+		Err:        errors.New("recipient wallet not found"),
+		Message:    "recipient wallet not found",
+		StatusCode: http.StatusNotFound,
+	}
+	ErrHoldNotFound = &AppError{
+		Err:        errors.New("hold not found"),
+		Message:    "hold not found",
+		StatusCode: http.StatusNotFound,
+	}
+	ErrHoldNotActive = &AppError{
+		Err:        errors.New("hold is no longer active"),
+		Message:    "hold is no longer active",
+		StatusCode: http.StatusConflict,
+	}
+	ErrHoldExpired = &AppError{
+		Err:        errors.New("hold has expired"),
+		Message:    "hold has expired",
+		StatusCode: http.StatusConflict,
+	}
+	ErrHoldIdMismatch = &AppError{
+		Err:        errors.New("hold_id was already used for a different request"),
+		Message:    "hold_id was already used for a different request",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrCaptureExceedsHold = &AppError{
+		Err:        errors.New("capture amount exceeds held amount"),
+		Message:    "capture amount exceeds held amount",
+		StatusCode: http.StatusBadRequest,
+	}
+	ErrSelfTransfer = &AppError{
+		Err:        errors.New("cannot transfer to own wallet"),
+		Message:    "cannot transfer to own wallet",
+		StatusCode: http.StatusBadRequest,
+	}
+	ErrIdempotencyKeyMismatch = &AppError{
+		Err:        errors.New("txn_id was already used for a different request"),
+		Message:    "txn_id was already used for a different request",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrTransactionNotFound = &AppError{
+		Err:        errors.New("transaction not found"),
+		Message:    "transaction not found",
+		StatusCode: http.StatusNotFound,
+	}
+	ErrTransactionNotRefundable = &AppError{
+		Err:        errors.New("only spend transactions can be refunded"),
+		Message:    "only spend transactions can be refunded",
+		StatusCode: http.StatusConflict,
+	}
+	ErrRefundExceedsOriginal = &AppError{
+		Err:        errors.New("refund exceeds the amount left to refund"),
+		Message:    "refund exceeds the amount left to refund",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrCampaignNotFound = &AppError{
+		Err:        errors.New("campaign not found"),
+		Message:    "campaign not found",
+		StatusCode: http.StatusNotFound,
+	}
+	ErrCampaignNotActive = &AppError{
+		Err:        errors.New("campaign is not active"),
+		Message:    "campaign is not active",
+		StatusCode: http.StatusConflict,
+	}
+	ErrCampaignEnded = &AppError{
+		Err:        errors.New("campaign has ended"),
+		Message:    "campaign has ended",
+		StatusCode: http.StatusConflict,
+	}
+	ErrCampaignAlreadyClaimed = &AppError{
+		Err:        errors.New("user already claimed this campaign"),
+		Message:    "user already claimed this campaign",
+		StatusCode: http.StatusConflict,
+	}
+	ErrCampaignBudgetExhausted = &AppError{
+		Err:        errors.New("campaign budget is exhausted"),
+		Message:    "campaign budget is exhausted",
+		StatusCode: http.StatusConflict,
+	}
+	ErrCampaignBudgetBelowSpent = &AppError{
+		Err:        errors.New("campaign budget cannot be lower than the amount already spent"),
+		Message:    "campaign budget cannot be lower than the amount already spent",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrCampaignAmountRequired = &AppError{
+		Err:        errors.New("amount is required for this campaign"),
+		Message:    "amount is required for this campaign",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrReferrerNotFound = &AppError{
+		Err:        errors.New("referrer not found"),
+		Message:    "referrer not found",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrUserNotFound = &AppError{
+		Err:        errors.New("user not found"),
+		Message:    "user not found",
+		StatusCode: http.StatusNotFound,
+	}
+	ErrInvalidRefreshToken = &AppError{
+		Err:        errors.New("invalid refresh token"),
+		Message:    "invalid refresh token",
+		StatusCode: http.StatusUnauthorized,
+	}
+	ErrInvalidCurrentPassword = &AppError{
+		Err:        errors.New("current password is incorrect"),
+		Message:    "current password is incorrect",
+		StatusCode: http.StatusForbidden,
+	}
+	ErrPasswordUnchanged = &AppError{
+		Err:        errors.New("new password must differ from the current one"),
+		Message:    "new password must differ from the current one",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrTooManyAttempts = &AppError{
+		Err:        errors.New("too many failed signin attempts, try again later"),
+		Message:    "too many failed signin attempts, try again later",
+		StatusCode: http.StatusTooManyRequests,
+	}
+	ErrForbidden = &AppError{
+		Err:        errors.New("forbidden"),
+		Message:    "forbidden",
+		StatusCode: http.StatusForbidden,
+	}
+	ErrInvalidRole = &AppError{
+		Err:        errors.New("role must be one of USER, SUPPORT, ADMIN, FINANCE"),
+		Message:    "role must be one of USER, SUPPORT, ADMIN, FINANCE",
+		StatusCode: http.StatusUnprocessableEntity,
+	}
+	ErrOwnRoleChange = &AppError{
+		Err:        errors.New("cannot change your own role"),
+		Message:    "cannot change your own role",
+		StatusCode: http.StatusForbidden,
+	}
+	ErrInvalidCSRFToken = &AppError{
+		Err:        errors.New("invalid csrf token"),
+		Message:    "invalid csrf token",
+		StatusCode: http.StatusForbidden,
+	}
+	ErrRefreshTokenReused = &AppError{
+		Err:        errors.New("refresh token was already used, session revoked"),
+		Message:    "refresh token was already used, session revoked",
+		StatusCode: http.StatusUnauthorized,
+	}
 )
 
+// RetryAfterError is an AppError the client may retry once RetryAfter has
+// passed.
+type RetryAfterError struct {
+	Err        *AppError
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
 // NewAppError creates a new AppError
 func NewAppError(err error, message string, statusCode int) *AppError {
 	return &AppError{
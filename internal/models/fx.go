@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// QuoteStatusActive is a quote that can still be converted
+	QuoteStatusActive = "ACTIVE"
+	// QuoteStatusUsed is a quote a conversion already used
+	QuoteStatusUsed = "USED"
+	// QuoteStatusExpired is a quote that ran out before it was used
+	QuoteStatusExpired = "EXPIRED"
+)
+
+// FxRateRequest sets the rate from one asset to another. Rate is a decimal
+// string: how many whole units of ToAsset one whole unit of FromAsset buys.
+type FxRateRequest struct {
+	FromAsset string `json:"from_asset" validate:"required,alphanum,max=16"`
+	ToAsset string `json:"to_asset" validate:"required,alphanum,max=16"`
+	Rate string `json:"rate" validate:"required,max=40"`
+	// ValidFrom defaults to now
+	ValidFrom *time.Time `json:"valid_from"`
+	// ValidUntil leaves the rate in effect until it is ended when unset
+	ValidUntil *time.Time `json:"valid_until"`
+}
+
+type FxRateServiceParams struct {
+	FxRateRequest
+	// ActorId is the operator setting the rate
+	ActorId uuid.UUID
+}
+
+type FxRateResponse struct {
+	Id uuid.UUID `json:"id"`
+	FromAsset string `json:"from_asset"`
+	ToAsset string `json:"to_asset"`
+	Rate string `json:"rate"`
+	ValidFrom time.Time `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+	// Active is set while now falls inside the rate's validity window
+	Active bool `json:"active"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// QuoteRequest asks for the price of converting Amount of FromAsset into
+// ToAsset. The quote holds that price for ExpiresIn seconds.
+type QuoteRequest struct {
+	FromAsset string `json:"from_asset" validate:"required,alphanum,max=16"`
+	ToAsset string `json:"to_asset" validate:"required,alphanum,max=16"`
+	Amount Amount `json:"amount" validate:"required,gt=0,lte=9007199254740991"`
+	// ExpiresIn is the quote lifetime in seconds
+	ExpiresIn int64 `json:"expires_in" validate:"omitempty,gt=0,lte=300"`
+}
+
+type QuoteServiceParams struct {
+	QuoteRequest
+	UserId uuid.UUID
+}
+
+type QuoteResponse struct {
+	Id uuid.UUID `json:"id"`
+	FromAsset string `json:"from_asset"`
+	ToAsset string `json:"to_asset"`
+	Rate string `json:"rate"`
+	FromAmount int64 `json:"from_amount"`
+	FormattedFromAmount string `json:"formatted_from_amount"`
+	ToAmount int64 `json:"to_amount"`
+	FormattedToAmount string `json:"formatted_to_amount"`
+	Status string `json:"status"`
+	TransactionId *uuid.UUID `json:"transaction_id,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ConvertRequest struct {
+	TxnId uuid.UUID `json:"txn_id" validate:"required"`
+	QuoteId uuid.UUID `json:"quote_id" validate:"required"`
+}
+
+type ConvertServiceParams struct {
+	ConvertRequest
+	UserId uuid.UUID
+}
+
+// ConvertResponse reports both sides of a conversion and the caller's new
+// balance in each asset.
+type ConvertResponse struct {
+	TransactionId uuid.UUID `json:"transaction_id"`
+	QuoteId uuid.UUID `json:"quote_id"`
+	FromAsset string `json:"from_asset"`
+	ToAsset string `json:"to_asset"`
+	Rate string `json:"rate"`
+	FromAmount int64 `json:"from_amount"`
+	FormattedFromAmount string `json:"formatted_from_amount"`
+	ToAmount int64 `json:"to_amount"`
+	FormattedToAmount string `json:"formatted_to_amount"`
+	FromBalance int64 `json:"from_balance"`
+	FormattedFromBalance string `json:"formatted_from_balance"`
+	ToBalance int64 `json:"to_balance"`
+	FormattedToBalance string `json:"formatted_to_balance"`
+}
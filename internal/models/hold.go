@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HoldRequest struct {
+	HoldId uuid.UUID `json:"hold_id" validate:"required"`
+	Amount Amount `json:"amount" validate:"required,gt=0,lte=9007199254740991"`
+	Asset string `json:"asset" validate:"omitempty,alphanum,max=16"`
+	// ExpiresIn is the hold lifetime in seconds
+	ExpiresIn int64 `json:"expires_in" validate:"omitempty,gt=0,lte=604800"`
+}
+
+type HoldServiceParams struct {
+	HoldRequest
+	UserId uuid.UUID
+}
+
+type CaptureRequest struct {
+	TxnId uuid.UUID `json:"txn_id" validate:"required"`
+	// Amount captures part of the hold; zero captures the full amount
+	Amount Amount `json:"amount" validate:"omitempty,gt=0,lte=9007199254740991"`
+}
+
+type CaptureServiceParams struct {
+	CaptureRequest
+	UserId uuid.UUID
+	HoldId uuid.UUID
+}
+
+type HoldResponse struct {
+	Id uuid.UUID `json:"id"`
+	WalletId uuid.UUID `json:"wallet_id"`
+	Amount int64 `json:"amount"`
+	FormattedAmount string `json:"formatted_amount"`
+	CapturedAmount int64 `json:"captured_amount"`
+	Status string `json:"status"`
+	CaptureTransactionId *uuid.UUID `json:"capture_transaction_id,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	AvailableBalance int64 `json:"available_balance"`
+	FormattedAvailableBalance string `json:"formatted_available_balance"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// JWKSet is a JSON Web Key Set (RFC 7517) of the public keys that verify
+// access tokens.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is one public key. RSA keys fill N and E, Ed25519 keys fill Crv and X.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X string `json:"x,omitempty"`
+}
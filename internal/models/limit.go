@@ -0,0 +1,85 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// Limits a transaction can break, as reported in LimitBreach.Limit.
+const (
+	LimitPerTransaction = "PER_TRANSACTION"
+	LimitDaily = "DAILY"
+	LimitMonthly = "MONTHLY"
+	LimitMaxBalance = "MAX_BALANCE"
+)
+
+// LimitExceededError is returned when a transaction would break one of the
+// user's limits. Limit is sent to the client as the response data.
+type LimitExceededError struct {
+	Err *AppError
+	Limit LimitBreach
+}
+
+func (e *LimitExceededError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LimitExceededError) Unwrap() error {
+	return e.Err
+}
+
+type LimitBreach struct {
+	Limit string `json:"limit"`
+	TransactionType string `json:"transaction_type"`
+	Asset string `json:"asset"`
+	Cap int64 `json:"cap"`
+	FormattedCap string `json:"formatted_cap"`
+	// Used is what the window already holds: the day's or month's total, or
+	// the balance for MAX_BALANCE, and 0 for PER_TRANSACTION
+	Used int64 `json:"used"`
+	FormattedUsed string `json:"formatted_used"`
+	Requested int64 `json:"requested"`
+	FormattedRequested string `json:"formatted_requested"`
+}
+
+type LimitTierRequest struct {
+	Tier string `json:"tier" validate:"required,oneof=STANDARD VERIFIED"`
+}
+
+// TransactionLimitRequest sets a user's own caps for one transaction type
+// in one asset. A cap left out or null means no cap.
+type TransactionLimitRequest struct {
+	Asset string `json:"asset" validate:"required,alphanum,max=16"`
+	TransactionType string `json:"transaction_type" validate:"required,oneof=TOPUP SPEND TRANSFER CONVERT"`
+	PerTransaction *int64 `json:"per_transaction" validate:"omitempty,gt=0,lte=9007199254740991"`
+	Daily *int64 `json:"daily" validate:"omitempty,gt=0,lte=9007199254740991"`
+	Monthly *int64 `json:"monthly" validate:"omitempty,gt=0,lte=9007199254740991"`
+}
+
+type BalanceLimitRequest struct {
+	Asset string `json:"asset" validate:"required,alphanum,max=16"`
+	MaxBalance *int64 `json:"max_balance" validate:"omitempty,gt=0,lte=9007199254740991"`
+}
+
+type UserLimitsResponse struct {
+	UserId uuid.UUID `json:"user_id"`
+	Tier string `json:"tier"`
+	TransactionLimits []TransactionLimitResponse `json:"transaction_limits"`
+	BalanceLimits []BalanceLimitResponse `json:"balance_limits"`
+}
+
+// TransactionLimitResponse is the limit in force for one transaction type
+// and asset. Source is TIER, or USER for an override.
+type TransactionLimitResponse struct {
+	Asset string `json:"asset"`
+	TransactionType string `json:"transaction_type"`
+	PerTransaction *int64 `json:"per_transaction"`
+	Daily *int64 `json:"daily"`
+	Monthly *int64 `json:"monthly"`
+	Source string `json:"source"`
+}
+
+type BalanceLimitResponse struct {
+	Asset string `json:"asset"`
+	MaxBalance *int64 `json:"max_balance"`
+	Source string `json:"source"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UnbalancedTransaction struct {
+	TransactionId uuid.UUID `json:"transaction_id"`
+	TransactionType string `json:"transaction_type"`
+	AssetId uuid.UUID `json:"asset_id"`
+	Net int64 `json:"net"`
+}
+
+type OrphanTransaction struct {
+	TransactionId uuid.UUID `json:"transaction_id"`
+	TransactionType string `json:"transaction_type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type NegativeBalance struct {
+	WalletId uuid.UUID `json:"wallet_id"`
+	UserId uuid.UUID `json:"user_id"`
+	AssetId uuid.UUID `json:"asset_id"`
+	Balance int64 `json:"balance"`
+}
+
+type ReconciliationSummary struct {
+	UnbalancedTransactions int `json:"unbalanced_transactions"`
+	OrphanTransactions int `json:"orphan_transactions"`
+	BalanceMismatches int `json:"balance_mismatches"`
+	NegativeBalances int `json:"negative_balances"`
+}
+
+type ReconciliationReport struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Consistent bool `json:"consistent"`
+	Summary ReconciliationSummary `json:"summary"`
+	UnbalancedTransactions []UnbalancedTransaction `json:"unbalanced_transactions"`
+	OrphanTransactions []OrphanTransaction `json:"orphan_transactions"`
+	BalanceMismatches []BalanceMismatch `json:"balance_mismatches"`
+	NegativeBalances []NegativeBalance `json:"negative_balances"`
+}
@@ -0,0 +1,93 @@
+package models
+
+// Role is the back-office role of an account. Every account signs up as
+// RoleUser; the other roles only open routes under /api/admin.
+type Role string
+
+const (
+	RoleUser    Role = "USER"
+	RoleSupport Role = "SUPPORT"
+	RoleAdmin   Role = "ADMIN"
+	RoleFinance Role = "FINANCE"
+)
+
+// Permission names an action on the admin API. Routes require permissions
+// rather than roles, so what a role may do is decided in one place.
+type Permission string
+
+const (
+	PermUsersRead          Permission = "users:read"
+	PermRolesManage        Permission = "roles:manage"
+	PermWalletsRead        Permission = "wallets:read"
+	PermWalletsFreeze      Permission = "wallets:freeze"
+	PermWalletsClose       Permission = "wallets:close"
+	PermLimitsManage       Permission = "limits:manage"
+	PermTransactionsRefund Permission = "transactions:refund"
+	PermAssetsRead         Permission = "assets:read"
+	PermAssetsManage       Permission = "assets:manage"
+	PermFxRead             Permission = "fx:read"
+	PermFxManage           Permission = "fx:manage"
+	PermCampaignsRead      Permission = "campaigns:read"
+	PermCampaignsManage    Permission = "campaigns:manage"
+	PermLedgerReconcile    Permission = "ledger:reconcile"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleSupport: {
+		PermUsersRead,
+		PermWalletsRead,
+		PermWalletsFreeze,
+		PermAssetsRead,
+		PermFxRead,
+		PermCampaignsRead,
+	},
+	RoleFinance: {
+		PermUsersRead,
+		PermWalletsRead,
+		PermWalletsClose,
+		PermLimitsManage,
+		PermTransactionsRefund,
+		PermAssetsRead,
+		PermAssetsManage,
+		PermFxRead,
+		PermFxManage,
+		PermCampaignsRead,
+		PermCampaignsManage,
+		PermLedgerReconcile,
+	},
+	RoleAdmin: {
+		PermUsersRead,
+		PermRolesManage,
+		PermWalletsRead,
+		PermWalletsFreeze,
+		PermWalletsClose,
+		PermLimitsManage,
+		PermTransactionsRefund,
+		PermAssetsRead,
+		PermAssetsManage,
+		PermFxRead,
+		PermFxManage,
+		PermCampaignsRead,
+		PermCampaignsManage,
+		PermLedgerReconcile,
+	},
+}
+
+// Can reports whether the role grants the permission.
+func (r Role) Can(perm Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid reports whether r is one of the known roles.
+func (r Role) Valid() bool {
+	switch r {
+	case RoleUser, RoleSupport, RoleAdmin, RoleFinance:
+		return true
+	}
+	return false
+}
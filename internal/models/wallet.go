@@ -1,12 +1,15 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type WalletRequest struct {
 	TxnId uuid.UUID `json:"txn_id" validate:"required"`
-	Amount int64 `json:"amount" validate:"required,gt=0"`
+	Amount Amount `json:"amount" validate:"required,gt=0,lte=9007199254740991"`
+	Asset string `json:"asset" validate:"omitempty,alphanum,max=16"`
 }
 
 type Wallet struct {
@@ -16,10 +19,95 @@ type Wallet struct {
 type WalletServiceParams struct {
 	WalletRequest
 	UserId uuid.UUID
-	WalletId uuid.UUID
 }
 
 type WalletResponse struct {
 	Message string `json:"message"`
+	WalletBalance
+}
+
+// WalletBalance is the data of a top-up, spend or transfer response: the new
+// balance in minor units and as a decimal in units of the asset.
+type WalletBalance struct {
 	Balance int64 `json:"balance"`
-}
\ No newline at end of file
+	FormattedBalance string `json:"formatted_balance"`
+}
+type TransferRequest struct {
+	TxnId uuid.UUID `json:"txn_id" validate:"required"`
+	Amount Amount `json:"amount" validate:"required,gt=0,lte=9007199254740991"`
+	RecipientUsername string `json:"recipient_username" validate:"required_without=RecipientWalletId,excluded_with=RecipientWalletId"`
+	RecipientWalletId *uuid.UUID `json:"recipient_wallet_id" validate:"required_without=RecipientUsername"`
+	Asset string `json:"asset" validate:"omitempty,alphanum,max=16"`
+}
+
+type TransferServiceParams struct {
+	TransferRequest
+	UserId uuid.UUID
+}
+
+type TransactionHistoryRequest struct {
+	Limit int32 `validate:"min=1,max=100"`
+	Type string `validate:"omitempty,oneof=SPEND TOPUP BONUS TRANSFER REFUND CONVERT"`
+	Order string `validate:"oneof=asc desc"`
+	Asset string `validate:"omitempty,alphanum,max=16"`
+	From *time.Time
+	To *time.Time
+	CursorSeq *int64
+}
+
+type TransactionHistoryParams struct {
+	TransactionHistoryRequest
+	UserId uuid.UUID
+}
+
+type TransactionHistoryEntry struct {
+	LedgerId uuid.UUID `json:"ledger_id"`
+	TransactionId uuid.UUID `json:"transaction_id"`
+	TransactionType string `json:"transaction_type"`
+	Amount int64 `json:"amount"`
+	FormattedAmount string `json:"formatted_amount"`
+	CreatedAt time.Time `json:"created_at"`
+	TransactionCreatedAt time.Time `json:"transaction_created_at"`
+	ParentTransactionId *uuid.UUID `json:"parent_transaction_id,omitempty"`
+	RefundedAmount int64 `json:"refunded_amount"`
+}
+
+type TransactionHistoryResponse struct {
+	Entries []TransactionHistoryEntry `json:"entries"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type BalanceResponse struct {
+	Asset string `json:"asset"`
+	// Status is ACTIVE unless an operator froze or closed the wallet
+	Status string `json:"status"`
+	// Decimals is how many of the balance's digits sit after the decimal point
+	Decimals int16 `json:"decimals"`
+	Balance int64 `json:"balance"`
+	FormattedBalance string `json:"formatted_balance"`
+	AvailableBalance int64 `json:"available_balance"`
+	FormattedAvailableBalance string `json:"formatted_available_balance"`
+}
+
+type RefundRequest struct {
+	TxnId uuid.UUID `json:"txn_id" validate:"required"`
+	Amount Amount `json:"amount" validate:"omitempty,gt=0,lte=9007199254740991"`
+}
+
+type RefundServiceParams struct {
+	RefundRequest
+	// ActorId is the operator making the refund
+	ActorId uuid.UUID
+	TransactionId uuid.UUID
+}
+
+type RefundResponse struct {
+	TransactionId uuid.UUID `json:"transaction_id"`
+	ParentTransactionId uuid.UUID `json:"parent_transaction_id"`
+	Amount int64 `json:"amount"`
+	FormattedAmount string `json:"formatted_amount"`
+	OriginalAmount int64 `json:"original_amount"`
+	RefundedAmount int64 `json:"refunded_amount"`
+	Balance int64 `json:"balance"`
+	FormattedBalance string `json:"formatted_balance"`
+}
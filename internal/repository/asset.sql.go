@@ -9,10 +9,54 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const assetHasLedgers = `-- name: AssetHasLedgers :one
+SELECT EXISTS (
+  SELECT 1
+  FROM ledgers l
+  JOIN wallets w ON w.id = l.wallet_id
+  WHERE w.asset_id = $1
+)
+`
+
+func (q *Queries) AssetHasLedgers(ctx context.Context, assetID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, assetHasLedgers, assetID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const createAsset = `-- name: CreateAsset :one
+INSERT INTO assets (code, name, decimals)
+VALUES ($1, $2, $3)
+RETURNING id, code, name, created_at, decimals, status, updated_at
+`
+
+type CreateAssetParams struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Decimals int16  `json:"decimals"`
+}
+
+func (q *Queries) CreateAsset(ctx context.Context, arg CreateAssetParams) (Asset, error) {
+	row := q.db.QueryRow(ctx, createAsset, arg.Code, arg.Name, arg.Decimals)
+	var i Asset
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.CreatedAt,
+		&i.Decimals,
+		&i.Status,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getAssetByCode = `-- name: GetAssetByCode :one
-SELECT id, code, name, created_at
+SELECT id, code, name, created_at, decimals, status, updated_at
 FROM assets
 WHERE code = $1
 `
@@ -25,12 +69,15 @@ func (q *Queries) GetAssetByCode(ctx context.Context, code string) (Asset, error
 		&i.Code,
 		&i.Name,
 		&i.CreatedAt,
+		&i.Decimals,
+		&i.Status,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getAssetById = `-- name: GetAssetById :one
-SELECT id, code, name, created_at
+SELECT id, code, name, created_at, decimals, status, updated_at
 FROM assets
 WHERE id = $1
 `
@@ -43,6 +90,102 @@ func (q *Queries) GetAssetById(ctx context.Context, id uuid.UUID) (Asset, error)
 		&i.Code,
 		&i.Name,
 		&i.CreatedAt,
+		&i.Decimals,
+		&i.Status,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listAssets = `-- name: ListAssets :many
+SELECT id, code, name, created_at, decimals, status, updated_at
+FROM assets
+ORDER BY code
+`
+
+func (q *Queries) ListAssets(ctx context.Context) ([]Asset, error) {
+	rows, err := q.db.Query(ctx, listAssets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Asset
+	for rows.Next() {
+		var i Asset
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.CreatedAt,
+			&i.Decimals,
+			&i.Status,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAsset = `-- name: LockAsset :one
+SELECT id, code, name, created_at, decimals, status, updated_at
+FROM assets
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockAsset(ctx context.Context, id uuid.UUID) (Asset, error) {
+	row := q.db.QueryRow(ctx, lockAsset, id)
+	var i Asset
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.CreatedAt,
+		&i.Decimals,
+		&i.Status,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateAsset = `-- name: UpdateAsset :one
+UPDATE assets
+SET name = COALESCE($1, name),
+    decimals = COALESCE($2, decimals),
+    status = COALESCE($3, status),
+    updated_at = now()
+WHERE id = $4
+RETURNING id, code, name, created_at, decimals, status, updated_at
+`
+
+type UpdateAssetParams struct {
+	Name     pgtype.Text     `json:"name"`
+	Decimals pgtype.Int2     `json:"decimals"`
+	Status   NullAssetStatus `json:"status"`
+	ID       uuid.UUID       `json:"id"`
+}
+
+func (q *Queries) UpdateAsset(ctx context.Context, arg UpdateAssetParams) (Asset, error) {
+	row := q.db.QueryRow(ctx, updateAsset,
+		arg.Name,
+		arg.Decimals,
+		arg.Status,
+		arg.ID,
+	)
+	var i Asset
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.CreatedAt,
+		&i.Decimals,
+		&i.Status,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
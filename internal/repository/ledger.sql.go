@@ -9,16 +9,17 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createLedger = `-- name: CreateLedger :one
 INSERT INTO ledgers (amount, transaction_id, wallet_id)
 VALUES ($1, $2, $3)
-RETURNING id, amount, transaction_id, wallet_id, created_at
+RETURNING id, amount, transaction_id, wallet_id, created_at, seq
 `
 
 type CreateLedgerParams struct {
-	Amount        int32     `json:"amount"`
+	Amount        int64     `json:"amount"`
 	TransactionID uuid.UUID `json:"transaction_id"`
 	WalletID      uuid.UUID `json:"wallet_id"`
 }
@@ -32,25 +33,13 @@ func (q *Queries) CreateLedger(ctx context.Context, arg CreateLedgerParams) (Led
 		&i.TransactionID,
 		&i.WalletID,
 		&i.CreatedAt,
+		&i.Seq,
 	)
 	return i, err
 }
 
-const getBalance = `-- name: GetBalance :one
-SELECT COALESCE(SUM(amount), 0)
-FROM ledgers
-WHERE wallet_id = $1
-`
-
-func (q *Queries) GetBalance(ctx context.Context, walletID uuid.UUID) (interface{}, error) {
-	row := q.db.QueryRow(ctx, getBalance, walletID)
-	var coalesce interface{}
-	err := row.Scan(&coalesce)
-	return coalesce, err
-}
-
 const getLedgerById = `-- name: GetLedgerById :one
-SELECT id, amount, transaction_id, wallet_id, created_at
+SELECT id, amount, transaction_id, wallet_id, created_at, seq
 FROM ledgers
 WHERE id = $1
 `
@@ -64,12 +53,13 @@ func (q *Queries) GetLedgerById(ctx context.Context, id uuid.UUID) (Ledger, erro
 		&i.TransactionID,
 		&i.WalletID,
 		&i.CreatedAt,
+		&i.Seq,
 	)
 	return i, err
 }
 
 const getLedgerByWalletAndTnx = `-- name: GetLedgerByWalletAndTnx :one
-SELECT id, amount, transaction_id, wallet_id, created_at
+SELECT id, amount, transaction_id, wallet_id, created_at, seq
 FROM ledgers
 WHERE wallet_id = $1 AND transaction_id = $2
 `
@@ -88,49 +78,119 @@ func (q *Queries) GetLedgerByWalletAndTnx(ctx context.Context, arg GetLedgerByWa
 		&i.TransactionID,
 		&i.WalletID,
 		&i.CreatedAt,
+		&i.Seq,
 	)
 	return i, err
 }
 
-const getLedgersByWalletId = `-- name: GetLedgersByWalletId :many
-SELECT id, amount, transaction_id, wallet_id, created_at
+const getLedgersByTransactionId = `-- name: GetLedgersByTransactionId :many
+SELECT id, amount, transaction_id, wallet_id, created_at, seq
 FROM ledgers
-WHERE wallet_id = $1
+WHERE transaction_id = $1
+ORDER BY amount
+`
+
+func (q *Queries) GetLedgersByTransactionId(ctx context.Context, transactionID uuid.UUID) ([]Ledger, error) {
+	rows, err := q.db.Query(ctx, getLedgersByTransactionId, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Ledger{}
+	for rows.Next() {
+		var i Ledger
+		if err := rows.Scan(
+			&i.ID,
+			&i.Amount,
+			&i.TransactionID,
+			&i.WalletID,
+			&i.CreatedAt,
+			&i.Seq,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLedgersByWalletId = `-- name: GetLedgersByWalletId :many
+SELECT l.id, l.amount, l.transaction_id, l.wallet_id, l.created_at, l.seq,
+  t.type AS transaction_type,
+  t.created_at AS transaction_created_at,
+  t.parent_transaction_id,
+  t.refunded_amount
+FROM ledgers l
+JOIN transactions t ON t.id = l.transaction_id
+WHERE l.wallet_id = $1
+  AND ($2::transaction_type IS NULL OR t.type = $2::transaction_type)
+  AND ($3::timestamptz IS NULL OR l.created_at >= $3::timestamptz)
+  AND ($4::timestamptz IS NULL OR l.created_at < $4::timestamptz)
+  AND (
+    $5::bigint IS NULL
+    OR ($6::text = 'asc' AND l.seq > $5::bigint)
+    OR ($6::text <> 'asc' AND l.seq < $5::bigint)
+  )
 ORDER BY
-  CASE WHEN $4 = 'asc'  THEN created_at END ASC,
-  CASE WHEN $4 = 'desc' THEN created_at END DESC,
-  created_at DESC
-LIMIT COALESCE($2, 5)
-OFFSET COALESCE($3, 0)
+  CASE WHEN $6::text = 'asc' THEN l.seq END ASC,
+  l.seq DESC
+LIMIT $7::int
 `
 
 type GetLedgersByWalletIdParams struct {
-	WalletID uuid.UUID   `json:"wallet_id"`
-	Column2  interface{} `json:"column_2"`
-	Column3  interface{} `json:"column_3"`
-	Column4  interface{} `json:"column_4"`
+	WalletID        uuid.UUID           `json:"wallet_id"`
+	TransactionType NullTransactionType `json:"transaction_type"`
+	FromTime        pgtype.Timestamptz  `json:"from_time"`
+	ToTime          pgtype.Timestamptz  `json:"to_time"`
+	CursorSeq       pgtype.Int8         `json:"cursor_seq"`
+	SortOrder       string              `json:"sort_order"`
+	RowLimit        int32               `json:"row_limit"`
 }
 
-func (q *Queries) GetLedgersByWalletId(ctx context.Context, arg GetLedgersByWalletIdParams) ([]Ledger, error) {
+type GetLedgersByWalletIdRow struct {
+	ID                   uuid.UUID          `json:"id"`
+	Amount               int64              `json:"amount"`
+	TransactionID        uuid.UUID          `json:"transaction_id"`
+	WalletID             uuid.UUID          `json:"wallet_id"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	Seq                  int64              `json:"seq"`
+	TransactionType      TransactionType    `json:"transaction_type"`
+	TransactionCreatedAt pgtype.Timestamptz `json:"transaction_created_at"`
+	ParentTransactionID  pgtype.UUID        `json:"parent_transaction_id"`
+	RefundedAmount       int64              `json:"refunded_amount"`
+}
+
+func (q *Queries) GetLedgersByWalletId(ctx context.Context, arg GetLedgersByWalletIdParams) ([]GetLedgersByWalletIdRow, error) {
 	rows, err := q.db.Query(ctx, getLedgersByWalletId,
 		arg.WalletID,
-		arg.Column2,
-		arg.Column3,
-		arg.Column4,
+		arg.TransactionType,
+		arg.FromTime,
+		arg.ToTime,
+		arg.CursorSeq,
+		arg.SortOrder,
+		arg.RowLimit,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Ledger{}
+	items := []GetLedgersByWalletIdRow{}
 	for rows.Next() {
-		var i Ledger
+		var i GetLedgersByWalletIdRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Amount,
 			&i.TransactionID,
 			&i.WalletID,
 			&i.CreatedAt,
+			&i.Seq,
+			&i.TransactionType,
+			&i.TransactionCreatedAt,
+			&i.ParentTransactionID,
+			&i.RefundedAmount,
 		); err != nil {
 			return nil, err
 		}
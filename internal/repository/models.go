@@ -7,17 +7,277 @@ package repository
 import (
 	"database/sql/driver"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AssetStatus string
+
+const (
+	AssetStatusACTIVE   AssetStatus = "ACTIVE"
+	AssetStatusINACTIVE AssetStatus = "INACTIVE"
+)
+
+func (e *AssetStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AssetStatus(s)
+	case string:
+		*e = AssetStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AssetStatus: %T", src)
+	}
+	return nil
+}
+
+type NullAssetStatus struct {
+	AssetStatus AssetStatus `json:"asset_status"`
+	Valid       bool        `json:"valid"` // Valid is true if AssetStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAssetStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.AssetStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AssetStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAssetStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AssetStatus), nil
+}
+
+type CampaignRule string
+
+const (
+	CampaignRuleSIGNUPBONUS     CampaignRule = "SIGNUP_BONUS"
+	CampaignRuleFIRSTTOPUPMATCH CampaignRule = "FIRST_TOPUP_MATCH"
+	CampaignRuleREFERRALREWARD  CampaignRule = "REFERRAL_REWARD"
+)
+
+func (e *CampaignRule) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CampaignRule(s)
+	case string:
+		*e = CampaignRule(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CampaignRule: %T", src)
+	}
+	return nil
+}
+
+type NullCampaignRule struct {
+	CampaignRule CampaignRule `json:"campaign_rule"`
+	Valid        bool         `json:"valid"` // Valid is true if CampaignRule is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCampaignRule) Scan(value interface{}) error {
+	if value == nil {
+		ns.CampaignRule, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CampaignRule.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCampaignRule) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CampaignRule), nil
+}
+
+type CampaignStatus string
+
+const (
+	CampaignStatusACTIVE CampaignStatus = "ACTIVE"
+	CampaignStatusPAUSED CampaignStatus = "PAUSED"
+	CampaignStatusENDED  CampaignStatus = "ENDED"
+)
+
+func (e *CampaignStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CampaignStatus(s)
+	case string:
+		*e = CampaignStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CampaignStatus: %T", src)
+	}
+	return nil
+}
+
+type NullCampaignStatus struct {
+	CampaignStatus CampaignStatus `json:"campaign_status"`
+	Valid          bool           `json:"valid"` // Valid is true if CampaignStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCampaignStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.CampaignStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CampaignStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCampaignStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CampaignStatus), nil
+}
+
+type HoldStatus string
+
+const (
+	HoldStatusACTIVE   HoldStatus = "ACTIVE"
+	HoldStatusCAPTURED HoldStatus = "CAPTURED"
+	HoldStatusVOIDED   HoldStatus = "VOIDED"
+	HoldStatusEXPIRED  HoldStatus = "EXPIRED"
+)
+
+func (e *HoldStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = HoldStatus(s)
+	case string:
+		*e = HoldStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for HoldStatus: %T", src)
+	}
+	return nil
+}
+
+type NullHoldStatus struct {
+	HoldStatus HoldStatus `json:"hold_status"`
+	Valid      bool       `json:"valid"` // Valid is true if HoldStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullHoldStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.HoldStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.HoldStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullHoldStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.HoldStatus), nil
+}
+
+type LimitTier string
+
+const (
+	LimitTierSTANDARD LimitTier = "STANDARD"
+	LimitTierVERIFIED LimitTier = "VERIFIED"
+)
+
+func (e *LimitTier) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LimitTier(s)
+	case string:
+		*e = LimitTier(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LimitTier: %T", src)
+	}
+	return nil
+}
+
+type NullLimitTier struct {
+	LimitTier LimitTier `json:"limit_tier"`
+	Valid     bool      `json:"valid"` // Valid is true if LimitTier is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLimitTier) Scan(value interface{}) error {
+	if value == nil {
+		ns.LimitTier, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LimitTier.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLimitTier) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LimitTier), nil
+}
+
+type LoginAttemptScope string
+
+const (
+	LoginAttemptScopeUSERNAME LoginAttemptScope = "USERNAME"
+	LoginAttemptScopeIP       LoginAttemptScope = "IP"
+)
+
+func (e *LoginAttemptScope) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LoginAttemptScope(s)
+	case string:
+		*e = LoginAttemptScope(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LoginAttemptScope: %T", src)
+	}
+	return nil
+}
+
+type NullLoginAttemptScope struct {
+	LoginAttemptScope LoginAttemptScope `json:"login_attempt_scope"`
+	Valid             bool              `json:"valid"` // Valid is true if LoginAttemptScope is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLoginAttemptScope) Scan(value interface{}) error {
+	if value == nil {
+		ns.LoginAttemptScope, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LoginAttemptScope.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLoginAttemptScope) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LoginAttemptScope), nil
+}
+
 type TransactionType string
 
 const (
-	TransactionTypeSPEND TransactionType = "SPEND"
-	TransactionTypeTOPUP TransactionType = "TOPUP"
-	TransactionTypeBONUS TransactionType = "BONUS"
+	TransactionTypeSPEND    TransactionType = "SPEND"
+	TransactionTypeTOPUP    TransactionType = "TOPUP"
+	TransactionTypeBONUS    TransactionType = "BONUS"
+	TransactionTypeTRANSFER TransactionType = "TRANSFER"
+	TransactionTypeREFUND   TransactionType = "REFUND"
+	TransactionTypeCONVERT  TransactionType = "CONVERT"
 )
 
 func (e *TransactionType) Scan(src interface{}) error {
@@ -55,11 +315,58 @@ func (ns NullTransactionType) Value() (driver.Value, error) {
 	return string(ns.TransactionType), nil
 }
 
+type UserRole string
+
+const (
+	UserRoleUSER    UserRole = "USER"
+	UserRoleSUPPORT UserRole = "SUPPORT"
+	UserRoleADMIN   UserRole = "ADMIN"
+	UserRoleFINANCE UserRole = "FINANCE"
+)
+
+func (e *UserRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserRole(s)
+	case string:
+		*e = UserRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserRole: %T", src)
+	}
+	return nil
+}
+
+type NullUserRole struct {
+	UserRole UserRole `json:"user_role"`
+	Valid    bool     `json:"valid"` // Valid is true if UserRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserRole), nil
+}
+
 type WalletOwnerType string
 
 const (
-	WalletOwnerTypeUSER   WalletOwnerType = "USER"
-	WalletOwnerTypeSYSTEM WalletOwnerType = "SYSTEM"
+	WalletOwnerTypeUSER      WalletOwnerType = "USER"
+	WalletOwnerTypeSYSTEM    WalletOwnerType = "SYSTEM"
+	WalletOwnerTypePROMOTION WalletOwnerType = "PROMOTION"
+	WalletOwnerTypeCAMPAIGN  WalletOwnerType = "CAMPAIGN"
+	WalletOwnerTypeTREASURY  WalletOwnerType = "TREASURY"
 )
 
 func (e *WalletOwnerType) Scan(src interface{}) error {
@@ -97,32 +404,206 @@ func (ns NullWalletOwnerType) Value() (driver.Value, error) {
 	return string(ns.WalletOwnerType), nil
 }
 
+type WalletStatus string
+
+const (
+	WalletStatusACTIVE      WalletStatus = "ACTIVE"
+	WalletStatusFROZENDEBIT WalletStatus = "FROZEN_DEBIT"
+	WalletStatusFROZENALL   WalletStatus = "FROZEN_ALL"
+	WalletStatusCLOSED      WalletStatus = "CLOSED"
+)
+
+func (e *WalletStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WalletStatus(s)
+	case string:
+		*e = WalletStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WalletStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWalletStatus struct {
+	WalletStatus WalletStatus `json:"wallet_status"`
+	Valid        bool         `json:"valid"` // Valid is true if WalletStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWalletStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WalletStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WalletStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWalletStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WalletStatus), nil
+}
+
 type Asset struct {
 	ID        uuid.UUID          `json:"id"`
 	Code      string             `json:"code"`
 	Name      string             `json:"name"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Decimals  int16              `json:"decimals"`
+	Status    AssetStatus        `json:"status"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type BalanceCheckpoint struct {
+	WalletID  uuid.UUID `json:"wallet_id"`
+	LedgerSeq int64     `json:"ledger_seq"`
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BalanceLimit struct {
+	ID         uuid.UUID     `json:"id"`
+	Tier       NullLimitTier `json:"tier"`
+	UserID     pgtype.UUID   `json:"user_id"`
+	AssetID    uuid.UUID     `json:"asset_id"`
+	MaxBalance pgtype.Int8   `json:"max_balance"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type Campaign struct {
+	ID           uuid.UUID          `json:"id"`
+	Name         string             `json:"name"`
+	Rule         CampaignRule       `json:"rule"`
+	AssetID      uuid.UUID          `json:"asset_id"`
+	Status       CampaignStatus     `json:"status"`
+	Amount       int64              `json:"amount"`
+	MatchPercent int32              `json:"match_percent"`
+	MaxReward    pgtype.Int8        `json:"max_reward"`
+	Budget       int64              `json:"budget"`
+	Spent        int64              `json:"spent"`
+	WalletID     uuid.UUID          `json:"wallet_id"`
+	StartsAt     time.Time          `json:"starts_at"`
+	EndsAt       pgtype.Timestamptz `json:"ends_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+type CampaignClaim struct {
+	CampaignID    uuid.UUID `json:"campaign_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Amount        int64     `json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type FxQuote struct {
+	ID            uuid.UUID      `json:"id"`
+	UserID        uuid.UUID      `json:"user_id"`
+	RateID        uuid.UUID      `json:"rate_id"`
+	FromAssetID   uuid.UUID      `json:"from_asset_id"`
+	ToAssetID     uuid.UUID      `json:"to_asset_id"`
+	Rate          pgtype.Numeric `json:"rate"`
+	FromAmount    int64          `json:"from_amount"`
+	ToAmount      int64          `json:"to_amount"`
+	ExpiresAt     time.Time      `json:"expires_at"`
+	TransactionID pgtype.UUID    `json:"transaction_id"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type FxRate struct {
+	ID          uuid.UUID          `json:"id"`
+	FromAssetID uuid.UUID          `json:"from_asset_id"`
+	ToAssetID   uuid.UUID          `json:"to_asset_id"`
+	Rate        pgtype.Numeric     `json:"rate"`
+	ValidFrom   time.Time          `json:"valid_from"`
+	ValidUntil  pgtype.Timestamptz `json:"valid_until"`
+	CreatedBy   pgtype.UUID        `json:"created_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type Hold struct {
+	ID                   uuid.UUID   `json:"id"`
+	WalletID             uuid.UUID   `json:"wallet_id"`
+	Amount               int64       `json:"amount"`
+	CapturedAmount       int64       `json:"captured_amount"`
+	Status               HoldStatus  `json:"status"`
+	CaptureTransactionID pgtype.UUID `json:"capture_transaction_id"`
+	ExpiresAt            time.Time   `json:"expires_at"`
+	CreatedAt            time.Time   `json:"created_at"`
+	UpdatedAt            time.Time   `json:"updated_at"`
+}
+
+type IdempotencyKey struct {
+	TxnID        uuid.UUID `json:"txn_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	Operation    string    `json:"operation"`
+	Fingerprint  string    `json:"fingerprint"`
+	ResponseBody []byte    `json:"response_body"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
 type Ledger struct {
 	ID            uuid.UUID          `json:"id"`
-	Amount        int32              `json:"amount"`
+	Amount        int64              `json:"amount"`
 	TransactionID uuid.UUID          `json:"transaction_id"`
 	WalletID      uuid.UUID          `json:"wallet_id"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	Seq           int64              `json:"seq"`
+}
+
+type LoginAttempt struct {
+	Scope        LoginAttemptScope  `json:"scope"`
+	Subject      string             `json:"subject"`
+	Failures     int32              `json:"failures"`
+	LockedUntil  pgtype.Timestamptz `json:"locked_until"`
+	LastFailedAt time.Time          `json:"last_failed_at"`
+}
+
+type Session struct {
+	ID                uuid.UUID          `json:"id"`
+	UserID            uuid.UUID          `json:"user_id"`
+	RefreshTokenHash  string             `json:"refresh_token_hash"`
+	PreviousTokenHash pgtype.Text        `json:"previous_token_hash"`
+	UserAgent         string             `json:"user_agent"`
+	IpAddress         string             `json:"ip_address"`
+	ExpiresAt         time.Time          `json:"expires_at"`
+	RevokedAt         pgtype.Timestamptz `json:"revoked_at"`
+	LastUsedAt        time.Time          `json:"last_used_at"`
+	CreatedAt         time.Time          `json:"created_at"`
 }
 
 type Transaction struct {
-	ID        uuid.UUID          `json:"id"`
-	Type      TransactionType    `json:"type"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ID                  uuid.UUID          `json:"id"`
+	Type                TransactionType    `json:"type"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	ParentTransactionID pgtype.UUID        `json:"parent_transaction_id"`
+	RefundedAmount      int64              `json:"refunded_amount"`
+}
+
+type TransactionLimit struct {
+	ID              uuid.UUID       `json:"id"`
+	Tier            NullLimitTier   `json:"tier"`
+	UserID          pgtype.UUID     `json:"user_id"`
+	AssetID         uuid.UUID       `json:"asset_id"`
+	TransactionType TransactionType `json:"transaction_type"`
+	PerTransaction  pgtype.Int8     `json:"per_transaction"`
+	Daily           pgtype.Int8     `json:"daily"`
+	Monthly         pgtype.Int8     `json:"monthly"`
+	UpdatedAt       time.Time       `json:"updated_at"`
 }
 
 type User struct {
-	ID        uuid.UUID          `json:"id"`
-	Username  string             `json:"username"`
-	Password  string             `json:"password"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ID         uuid.UUID          `json:"id"`
+	Username   string             `json:"username"`
+	Password   string             `json:"password"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ReferredBy pgtype.UUID        `json:"referred_by"`
+	Role       UserRole           `json:"role"`
+	LimitTier  LimitTier          `json:"limit_tier"`
 }
 
 type Wallet struct {
@@ -131,4 +612,29 @@ type Wallet struct {
 	OwnerID   uuid.UUID          `json:"owner_id"`
 	AssetID   uuid.UUID          `json:"asset_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Status    WalletStatus       `json:"status"`
+}
+
+type WalletBalance struct {
+	WalletID  uuid.UUID `json:"wallet_id"`
+	Balance   int64     `json:"balance"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WalletBalanceDelta struct {
+	ID        int64     `json:"id"`
+	WalletID  uuid.UUID `json:"wallet_id"`
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WalletStatusChange struct {
+	ID                 uuid.UUID    `json:"id"`
+	WalletID           uuid.UUID    `json:"wallet_id"`
+	FromStatus         WalletStatus `json:"from_status"`
+	ToStatus           WalletStatus `json:"to_status"`
+	Reason             string       `json:"reason"`
+	ChangedBy          pgtype.UUID  `json:"changed_by"`
+	SweepTransactionID pgtype.UUID  `json:"sweep_transaction_id"`
+	CreatedAt          time.Time    `json:"created_at"`
 }
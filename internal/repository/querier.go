@@ -11,24 +11,105 @@ import (
 )
 
 type Querier interface {
+	AddCampaignSpent(ctx context.Context, arg AddCampaignSpentParams) (Campaign, error)
+	AddRefundedAmount(ctx context.Context, arg AddRefundedAmountParams) (Transaction, error)
+	ApplyWalletBalance(ctx context.Context, arg ApplyWalletBalanceParams) error
+	AssetHasLedgers(ctx context.Context, assetID uuid.UUID) (bool, error)
+	CaptureHold(ctx context.Context, arg CaptureHoldParams) (Hold, error)
+	ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (int64, error)
+	ClearLoginAttempts(ctx context.Context, arg ClearLoginAttemptsParams) error
+	CountWalletTopUps(ctx context.Context, walletID uuid.UUID) (int64, error)
+	CreateAsset(ctx context.Context, arg CreateAssetParams) (Asset, error)
+	CreateBalanceCheckpoint(ctx context.Context, walletID uuid.UUID) (BalanceCheckpoint, error)
+	CreateCampaign(ctx context.Context, arg CreateCampaignParams) (Campaign, error)
+	CreateCampaignClaim(ctx context.Context, arg CreateCampaignClaimParams) (CampaignClaim, error)
+	CreateChildTxn(ctx context.Context, arg CreateChildTxnParams) (Transaction, error)
+	CreateFxQuote(ctx context.Context, arg CreateFxQuoteParams) (FxQuote, error)
+	CreateFxRate(ctx context.Context, arg CreateFxRateParams) (FxRate, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
 	CreateLedger(ctx context.Context, arg CreateLedgerParams) (Ledger, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTxn(ctx context.Context, arg CreateTxnParams) (Transaction, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateWallet(ctx context.Context, arg CreateWalletParams) (Wallet, error)
+	CreateWalletStatusChange(ctx context.Context, arg CreateWalletStatusChangeParams) (WalletStatusChange, error)
+	DeleteUserBalanceLimit(ctx context.Context, arg DeleteUserBalanceLimitParams) (int64, error)
+	DeleteUserTransactionLimit(ctx context.Context, arg DeleteUserTransactionLimitParams) (int64, error)
+	EndFxRate(ctx context.Context, id uuid.UUID) (FxRate, error)
+	EnsureTierBalanceLimit(ctx context.Context, arg EnsureTierBalanceLimitParams) error
+	EnsureTierTransactionLimit(ctx context.Context, arg EnsureTierTransactionLimitParams) error
+	EnsureWallet(ctx context.Context, arg EnsureWalletParams) error
+	ExpireHolds(ctx context.Context, walletID uuid.UUID) error
+	FoldWalletBalanceDeltas(ctx context.Context) (int64, error)
+	FindNegativeUserBalances(ctx context.Context) ([]FindNegativeUserBalancesRow, error)
+	FindOrphanTransactions(ctx context.Context) ([]FindOrphanTransactionsRow, error)
+	FindUnbalancedTransactions(ctx context.Context) ([]FindUnbalancedTransactionsRow, error)
+	GetActiveHoldsTotal(ctx context.Context, walletID uuid.UUID) (int64, error)
 	GetAssetByCode(ctx context.Context, code string) (Asset, error)
 	GetAssetById(ctx context.Context, id uuid.UUID) (Asset, error)
-	GetBalance(ctx context.Context, walletID uuid.UUID) (interface{}, error)
+	GetBalance(ctx context.Context, walletID uuid.UUID) (int64, error)
+	GetBalanceLimit(ctx context.Context, arg GetBalanceLimitParams) (BalanceLimit, error)
+	GetCampaignById(ctx context.Context, id uuid.UUID) (Campaign, error)
+	GetCurrentFxRate(ctx context.Context, arg GetCurrentFxRateParams) (FxRate, error)
+	GetFxQuoteById(ctx context.Context, id uuid.UUID) (FxQuote, error)
+	GetFxRateById(ctx context.Context, id uuid.UUID) (FxRate, error)
+	GetHoldById(ctx context.Context, id uuid.UUID) (Hold, error)
+	GetIdempotencyKey(ctx context.Context, txnID uuid.UUID) (IdempotencyKey, error)
 	GetLedgerById(ctx context.Context, id uuid.UUID) (Ledger, error)
 	GetLedgerByWalletAndTnx(ctx context.Context, arg GetLedgerByWalletAndTnxParams) (Ledger, error)
-	GetLedgersByWalletId(ctx context.Context, arg GetLedgersByWalletIdParams) ([]Ledger, error)
-	GetSystemWallet(ctx context.Context) (uuid.UUID, error)
+	GetLedgersByTransactionId(ctx context.Context, transactionID uuid.UUID) ([]Ledger, error)
+	GetLedgersByWalletId(ctx context.Context, arg GetLedgersByWalletIdParams) ([]GetLedgersByWalletIdRow, error)
+	GetLimitUsage(ctx context.Context, arg GetLimitUsageParams) (GetLimitUsageRow, error)
+	GetPromotionWallet(ctx context.Context, assetID uuid.UUID) (uuid.UUID, error)
+	GetSessionById(ctx context.Context, id uuid.UUID) (Session, error)
+	GetSystemWallet(ctx context.Context, assetID uuid.UUID) (uuid.UUID, error)
 	GetTransactionById(ctx context.Context, id uuid.UUID) (Transaction, error)
 	GetTransactionByType(ctx context.Context, arg GetTransactionByTypeParams) ([]Transaction, error)
+	GetTransactionLimit(ctx context.Context, arg GetTransactionLimitParams) (TransactionLimit, error)
+	GetTreasuryWallet(ctx context.Context, assetID uuid.UUID) (uuid.UUID, error)
 	GetUserById(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
 	GetWalletById(ctx context.Context, id uuid.UUID) (Wallet, error)
-	GetWalletByOwner(ctx context.Context, ownerID uuid.UUID) (Wallet, error)
-	LockWallet(ctx context.Context, ownerID uuid.UUID) (uuid.UUID, error)
+	GetWalletByOwner(ctx context.Context, arg GetWalletByOwnerParams) (Wallet, error)
+	HasClaimedCampaign(ctx context.Context, arg HasClaimedCampaignParams) (bool, error)
+	ListActiveCampaigns(ctx context.Context, arg ListActiveCampaignsParams) ([]Campaign, error)
+	ListAssets(ctx context.Context) ([]Asset, error)
+	ListBalanceLimitsForUser(ctx context.Context, userID uuid.UUID) ([]BalanceLimit, error)
+	ListCampaigns(ctx context.Context) ([]Campaign, error)
+	ListFxRates(ctx context.Context) ([]FxRate, error)
+	ListTransactionLimitsForUser(ctx context.Context, userID uuid.UUID) ([]TransactionLimit, error)
+	ListWalletStatusChanges(ctx context.Context, walletID uuid.UUID) ([]WalletStatusChange, error)
+	ListWalletsByOwner(ctx context.Context, ownerID uuid.UUID) ([]Wallet, error)
+	ListWalletsDueForCheckpoint(ctx context.Context, minEntries int64) ([]uuid.UUID, error)
+	LockAsset(ctx context.Context, id uuid.UUID) (Asset, error)
+	LockCampaign(ctx context.Context, id uuid.UUID) (Campaign, error)
+	LockFxQuote(ctx context.Context, id uuid.UUID) (FxQuote, error)
+	LockHold(ctx context.Context, id uuid.UUID) (Hold, error)
+	LockLogin(ctx context.Context, arg LockLoginParams) error
+	LockSessionByToken(ctx context.Context, refreshTokenHash string) (Session, error)
+	LockTransaction(ctx context.Context, id uuid.UUID) (Transaction, error)
+	LockWallet(ctx context.Context, arg LockWalletParams) (Wallet, error)
+	LockWalletBalance(ctx context.Context, walletID uuid.UUID) (int64, error)
+	LockWalletById(ctx context.Context, id uuid.UUID) (Wallet, error)
+	ReleaseLoginAttempt(ctx context.Context, arg ReleaseLoginAttemptParams) (int32, error)
+	ReserveLoginAttempt(ctx context.Context, arg ReserveLoginAttemptParams) (ReserveLoginAttemptRow, error)
+	RescaleBalanceLimits(ctx context.Context, arg RescaleBalanceLimitsParams) error
+	RescaleTransactionLimits(ctx context.Context, arg RescaleTransactionLimitsParams) error
+	RevokeSession(ctx context.Context, id uuid.UUID) (int64, error)
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int64, error)
+	RotateSession(ctx context.Context, arg RotateSessionParams) (Session, error)
+	SaveIdempotencyResponse(ctx context.Context, arg SaveIdempotencyResponseParams) error
+	UpdateAsset(ctx context.Context, arg UpdateAssetParams) (Asset, error)
+	UpdateCampaign(ctx context.Context, arg UpdateCampaignParams) (Campaign, error)
+	UpdateUserLimitTier(ctx context.Context, arg UpdateUserLimitTierParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpdateWalletStatus(ctx context.Context, arg UpdateWalletStatusParams) (Wallet, error)
+	UpsertUserBalanceLimit(ctx context.Context, arg UpsertUserBalanceLimitParams) (BalanceLimit, error)
+	UpsertUserTransactionLimit(ctx context.Context, arg UpsertUserTransactionLimitParams) (TransactionLimit, error)
+	UseFxQuote(ctx context.Context, arg UseFxQuoteParams) error
+	VerifyWalletBalances(ctx context.Context) ([]VerifyWalletBalancesRow, error)
+	VoidHold(ctx context.Context, id uuid.UUID) (Hold, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -1,3 +1,8 @@
+-- name: CreateAsset :one
+INSERT INTO assets (code, name, decimals)
+VALUES ($1, $2, $3)
+RETURNING *;
+
 -- name: GetAssetById :one
 SELECT *
 FROM assets
@@ -6,4 +11,32 @@ WHERE id = $1;
 -- name: GetAssetByCode :one
 SELECT *
 FROM assets
-WHERE code = $1;
\ No newline at end of file
+WHERE code = $1;
+
+-- name: ListAssets :many
+SELECT *
+FROM assets
+ORDER BY code;
+
+-- name: LockAsset :one
+SELECT *
+FROM assets
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateAsset :one
+UPDATE assets
+SET name = COALESCE(sqlc.narg('name'), name),
+    decimals = COALESCE(sqlc.narg('decimals'), decimals),
+    status = COALESCE(sqlc.narg('status'), status),
+    updated_at = now()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: AssetHasLedgers :one
+SELECT EXISTS (
+  SELECT 1
+  FROM ledgers l
+  JOIN wallets w ON w.id = l.wallet_id
+  WHERE w.asset_id = $1
+);
\ No newline at end of file
@@ -0,0 +1,85 @@
+-- name: ApplyWalletBalance :exec
+WITH shared AS (
+  INSERT INTO wallet_balance_deltas (wallet_id, amount)
+  SELECT id, sqlc.arg(balance)::bigint
+  FROM wallets
+  WHERE id = sqlc.arg(wallet_id)::uuid AND owner_type <> 'USER'
+  RETURNING wallet_id
+)
+INSERT INTO wallet_balances (wallet_id, balance)
+SELECT sqlc.arg(wallet_id)::uuid, sqlc.arg(balance)::bigint
+WHERE NOT EXISTS (SELECT 1 FROM shared)
+ON CONFLICT (wallet_id) DO UPDATE
+SET balance = wallet_balances.balance + EXCLUDED.balance, updated_at = now();
+
+-- name: FoldWalletBalanceDeltas :execrows
+WITH folded AS (
+  DELETE FROM wallet_balance_deltas
+  RETURNING wallet_id, amount
+)
+INSERT INTO wallet_balances (wallet_id, balance)
+SELECT wallet_id, SUM(amount)
+FROM folded
+GROUP BY wallet_id
+ON CONFLICT (wallet_id) DO UPDATE
+SET balance = wallet_balances.balance + EXCLUDED.balance, updated_at = now();
+
+-- name: GetBalance :one
+SELECT (
+  COALESCE((SELECT balance FROM wallet_balances WHERE wallet_id = $1), 0)
+  + COALESCE((SELECT SUM(amount) FROM wallet_balance_deltas WHERE wallet_id = $1), 0)
+)::bigint AS balance;
+
+-- name: LockWalletBalance :one
+SELECT balance
+FROM wallet_balances
+WHERE wallet_id = $1
+FOR UPDATE;
+
+-- name: CreateBalanceCheckpoint :one
+WITH last AS (
+  SELECT ledger_seq, balance
+  FROM balance_checkpoints
+  WHERE wallet_id = $1
+  ORDER BY ledger_seq DESC
+  LIMIT 1
+)
+INSERT INTO balance_checkpoints (wallet_id, ledger_seq, balance)
+SELECT $1,
+  COALESCE(MAX(l.seq), (SELECT ledger_seq FROM last), 0),
+  COALESCE((SELECT balance FROM last), 0) + COALESCE(SUM(l.amount), 0)
+FROM ledgers l
+WHERE l.wallet_id = $1 AND l.seq > COALESCE((SELECT ledger_seq FROM last), 0)
+ON CONFLICT (wallet_id, ledger_seq) DO NOTHING
+RETURNING *;
+
+-- name: ListWalletsDueForCheckpoint :many
+SELECT w.id
+FROM wallets w
+WHERE w.owner_type = 'USER' AND (
+  SELECT COUNT(*)
+  FROM ledgers l
+  WHERE l.wallet_id = w.id
+    AND l.seq > COALESCE((SELECT MAX(c.ledger_seq) FROM balance_checkpoints c WHERE c.wallet_id = w.id), 0)
+) >= sqlc.arg(min_entries)::bigint
+ORDER BY w.id;
+
+-- name: VerifyWalletBalances :many
+SELECT w.id AS wallet_id, w.owner_type, w.asset_id,
+  (COALESCE(b.balance, 0) + COALESCE((
+    SELECT SUM(d.amount) FROM wallet_balance_deltas d WHERE d.wallet_id = w.id
+  ), 0))::bigint AS cached_balance,
+  (COALESCE(c.balance, 0) + COALESCE((
+    SELECT SUM(l.amount) FROM ledgers l WHERE l.wallet_id = w.id AND l.seq > COALESCE(c.ledger_seq, 0)
+  ), 0))::bigint AS checkpoint_balance,
+  COALESCE((SELECT SUM(l.amount) FROM ledgers l WHERE l.wallet_id = w.id), 0)::bigint AS ledger_balance
+FROM wallets w
+LEFT JOIN wallet_balances b ON b.wallet_id = w.id
+LEFT JOIN LATERAL (
+  SELECT ledger_seq, balance
+  FROM balance_checkpoints
+  WHERE wallet_id = w.id
+  ORDER BY ledger_seq DESC
+  LIMIT 1
+) c ON true
+ORDER BY w.id;
\ No newline at end of file
@@ -0,0 +1,65 @@
+-- name: CreateCampaign :one
+INSERT INTO campaigns (id, name, rule, asset_id, amount, match_percent, max_reward, budget, wallet_id, starts_at, ends_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetCampaignById :one
+SELECT *
+FROM campaigns
+WHERE id = $1;
+
+-- name: LockCampaign :one
+SELECT *
+FROM campaigns
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListCampaigns :many
+SELECT *
+FROM campaigns
+ORDER BY created_at DESC, id;
+
+-- name: ListActiveCampaigns :many
+SELECT *
+FROM campaigns
+WHERE rule = sqlc.arg('rule')
+  AND (sqlc.narg('asset_id')::uuid IS NULL OR asset_id = sqlc.narg('asset_id'))
+  AND status = 'ACTIVE'
+  AND starts_at <= now()
+  AND (ends_at IS NULL OR ends_at > now())
+  AND spent < budget
+ORDER BY id;
+
+-- name: UpdateCampaign :one
+UPDATE campaigns
+SET name = COALESCE(sqlc.narg('name'), name),
+    status = COALESCE(sqlc.narg('status'), status),
+    budget = COALESCE(sqlc.narg('budget'), budget),
+    ends_at = COALESCE(sqlc.narg('ends_at'), ends_at),
+    updated_at = now()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: AddCampaignSpent :one
+UPDATE campaigns
+SET spent = spent + $2, updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateCampaignClaim :one
+INSERT INTO campaign_claims (campaign_id, user_id, transaction_id, amount)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: HasClaimedCampaign :one
+SELECT EXISTS (
+  SELECT 1
+  FROM campaign_claims
+  WHERE campaign_id = $1 AND user_id = $2
+);
+
+-- name: CountWalletTopUps :one
+SELECT COUNT(*)
+FROM ledgers l
+JOIN transactions t ON t.id = l.transaction_id
+WHERE l.wallet_id = $1 AND t.type = 'TOPUP';
\ No newline at end of file
@@ -0,0 +1,49 @@
+-- name: CreateFxRate :one
+INSERT INTO fx_rates (from_asset_id, to_asset_id, rate, valid_from, valid_until, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetFxRateById :one
+SELECT *
+FROM fx_rates
+WHERE id = $1;
+
+-- name: ListFxRates :many
+SELECT *
+FROM fx_rates
+ORDER BY created_at DESC, id;
+
+-- name: GetCurrentFxRate :one
+SELECT *
+FROM fx_rates
+WHERE from_asset_id = $1 AND to_asset_id = $2
+  AND valid_from <= now() AND (valid_until IS NULL OR valid_until > now())
+ORDER BY valid_from DESC, created_at DESC
+LIMIT 1;
+
+-- name: EndFxRate :one
+UPDATE fx_rates
+SET valid_until = GREATEST(now(), valid_from)
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateFxQuote :one
+INSERT INTO fx_quotes (user_id, rate_id, from_asset_id, to_asset_id, rate, from_amount, to_amount, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetFxQuoteById :one
+SELECT *
+FROM fx_quotes
+WHERE id = $1;
+
+-- name: LockFxQuote :one
+SELECT *
+FROM fx_quotes
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UseFxQuote :exec
+UPDATE fx_quotes
+SET transaction_id = $2
+WHERE id = $1;
\ No newline at end of file
@@ -0,0 +1,37 @@
+-- name: CreateHold :one
+INSERT INTO holds (id, wallet_id, amount, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetHoldById :one
+SELECT *
+FROM holds
+WHERE id = $1;
+
+-- name: LockHold :one
+SELECT *
+FROM holds
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ExpireHolds :exec
+UPDATE holds
+SET status = 'EXPIRED', updated_at = now()
+WHERE wallet_id = $1 AND status = 'ACTIVE' AND expires_at <= now();
+
+-- name: GetActiveHoldsTotal :one
+SELECT COALESCE(SUM(amount - captured_amount), 0)::bigint AS total
+FROM holds
+WHERE wallet_id = $1 AND status = 'ACTIVE' AND expires_at > now();
+
+-- name: CaptureHold :one
+UPDATE holds
+SET status = 'CAPTURED', captured_amount = $2, capture_transaction_id = $3, updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: VoidHold :one
+UPDATE holds
+SET status = 'VOIDED', updated_at = now()
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- name: ClaimIdempotencyKey :execrows
+INSERT INTO idempotency_keys (txn_id, user_id, operation, fingerprint)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (txn_id) DO NOTHING;
+
+-- name: GetIdempotencyKey :one
+SELECT *
+FROM idempotency_keys
+WHERE txn_id = $1;
+
+-- name: SaveIdempotencyResponse :exec
+UPDATE idempotency_keys
+SET response_body = $2
+WHERE txn_id = $1;
\ No newline at end of file
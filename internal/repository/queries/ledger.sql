@@ -14,17 +14,29 @@ FROM ledgers
 WHERE wallet_id = $1 AND transaction_id = $2;
 
 -- name: GetLedgersByWalletId :many
-SELECT *
-FROM ledgers
-WHERE wallet_id = $1
+SELECT l.id, l.amount, l.transaction_id, l.wallet_id, l.created_at, l.seq,
+  t.type AS transaction_type,
+  t.created_at AS transaction_created_at,
+  t.parent_transaction_id,
+  t.refunded_amount
+FROM ledgers l
+JOIN transactions t ON t.id = l.transaction_id
+WHERE l.wallet_id = sqlc.arg(wallet_id)
+  AND (sqlc.narg(transaction_type)::transaction_type IS NULL OR t.type = sqlc.narg(transaction_type)::transaction_type)
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR l.created_at >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR l.created_at < sqlc.narg(to_time)::timestamptz)
+  AND (
+    sqlc.narg(cursor_seq)::bigint IS NULL
+    OR (sqlc.arg(sort_order)::text = 'asc' AND l.seq > sqlc.narg(cursor_seq)::bigint)
+    OR (sqlc.arg(sort_order)::text <> 'asc' AND l.seq < sqlc.narg(cursor_seq)::bigint)
+  )
 ORDER BY
-  CASE WHEN $4 = 'asc'  THEN created_at END ASC,
-  CASE WHEN $4 = 'desc' THEN created_at END DESC,
-  created_at DESC
-LIMIT COALESCE($2, 5)
-OFFSET COALESCE($3, 0);
+  CASE WHEN sqlc.arg(sort_order)::text = 'asc' THEN l.seq END ASC,
+  l.seq DESC
+LIMIT sqlc.arg(row_limit)::int;
 
--- name: GetBalance :one
-SELECT COALESCE(SUM(amount), 0)
+-- name: GetLedgersByTransactionId :many
+SELECT *
 FROM ledgers
-WHERE wallet_id = $1;
\ No newline at end of file
+WHERE transaction_id = $1
+ORDER BY amount;
\ No newline at end of file
@@ -0,0 +1,103 @@
+-- name: DeleteUserBalanceLimit :execrows
+DELETE FROM balance_limits
+WHERE user_id = sqlc.arg(user_id)::uuid
+  AND asset_id = sqlc.arg(asset_id);
+
+-- name: DeleteUserTransactionLimit :execrows
+DELETE FROM transaction_limits
+WHERE user_id = sqlc.arg(user_id)::uuid
+  AND asset_id = sqlc.arg(asset_id)
+  AND transaction_type = sqlc.arg(transaction_type);
+
+-- name: EnsureTierBalanceLimit :exec
+INSERT INTO balance_limits (tier, asset_id, max_balance)
+VALUES (sqlc.arg(tier)::limit_tier, sqlc.arg(asset_id), sqlc.arg(max_balance))
+ON CONFLICT (tier, asset_id) WHERE tier IS NOT NULL DO NOTHING;
+
+-- name: EnsureTierTransactionLimit :exec
+INSERT INTO transaction_limits (tier, asset_id, transaction_type, per_transaction, daily, monthly)
+VALUES (sqlc.arg(tier)::limit_tier, sqlc.arg(asset_id), sqlc.arg(transaction_type), sqlc.arg(per_transaction), sqlc.arg(daily), sqlc.arg(monthly))
+ON CONFLICT (tier, asset_id, transaction_type) WHERE tier IS NOT NULL DO NOTHING;
+
+-- name: GetBalanceLimit :one
+SELECT *
+FROM balance_limits
+WHERE asset_id = sqlc.arg(asset_id)
+  AND (user_id = sqlc.arg(user_id)::uuid
+    OR tier = (SELECT limit_tier FROM users WHERE id = sqlc.arg(user_id)::uuid))
+ORDER BY user_id NULLS LAST
+LIMIT 1;
+
+-- name: GetLimitUsage :one
+SELECT
+  COALESCE(SUM(ABS(l.amount)) FILTER (WHERE l.created_at >= date_trunc('day', now(), 'UTC')), 0)::bigint AS daily,
+  COALESCE(SUM(ABS(l.amount)), 0)::bigint AS monthly
+FROM ledgers l
+JOIN transactions t ON t.id = l.transaction_id
+WHERE l.wallet_id = sqlc.arg(wallet_id)
+  AND t.type = sqlc.arg(type)
+  AND (l.amount > 0) = sqlc.arg(credit)::boolean
+  AND l.created_at >= date_trunc('month', now(), 'UTC');
+
+-- name: GetTransactionLimit :one
+SELECT *
+FROM transaction_limits
+WHERE asset_id = sqlc.arg(asset_id)
+  AND transaction_type = sqlc.arg(transaction_type)
+  AND (user_id = sqlc.arg(user_id)::uuid
+    OR tier = (SELECT limit_tier FROM users WHERE id = sqlc.arg(user_id)::uuid))
+ORDER BY user_id NULLS LAST
+LIMIT 1;
+
+-- name: ListBalanceLimitsForUser :many
+SELECT *
+FROM balance_limits
+WHERE user_id = sqlc.arg(user_id)::uuid
+  OR tier = (SELECT limit_tier FROM users WHERE id = sqlc.arg(user_id)::uuid)
+ORDER BY asset_id, user_id NULLS LAST;
+
+-- name: ListTransactionLimitsForUser :many
+SELECT *
+FROM transaction_limits
+WHERE user_id = sqlc.arg(user_id)::uuid
+  OR tier = (SELECT limit_tier FROM users WHERE id = sqlc.arg(user_id)::uuid)
+ORDER BY asset_id, transaction_type, user_id NULLS LAST;
+
+-- name: RescaleBalanceLimits :exec
+UPDATE balance_limits
+SET max_balance = CASE WHEN max_balance IS NOT NULL
+      THEN LEAST(GREATEST(floor(max_balance * power(10::numeric, sqlc.arg(shift)::int)), 1), 9007199254740991)::bigint
+    END,
+    updated_at = now()
+WHERE asset_id = sqlc.arg(asset_id);
+
+-- name: RescaleTransactionLimits :exec
+UPDATE transaction_limits
+SET per_transaction = CASE WHEN per_transaction IS NOT NULL
+      THEN LEAST(GREATEST(floor(per_transaction * power(10::numeric, sqlc.arg(shift)::int)), 1), 9007199254740991)::bigint
+    END,
+    daily = CASE WHEN daily IS NOT NULL
+      THEN LEAST(GREATEST(floor(daily * power(10::numeric, sqlc.arg(shift)::int)), 1), 9007199254740991)::bigint
+    END,
+    monthly = CASE WHEN monthly IS NOT NULL
+      THEN LEAST(GREATEST(floor(monthly * power(10::numeric, sqlc.arg(shift)::int)), 1), 9007199254740991)::bigint
+    END,
+    updated_at = now()
+WHERE asset_id = sqlc.arg(asset_id);
+
+-- name: UpsertUserBalanceLimit :one
+INSERT INTO balance_limits (user_id, asset_id, max_balance)
+VALUES (sqlc.arg(user_id)::uuid, sqlc.arg(asset_id), sqlc.arg(max_balance))
+ON CONFLICT (user_id, asset_id) WHERE user_id IS NOT NULL
+DO UPDATE SET max_balance = EXCLUDED.max_balance, updated_at = now()
+RETURNING *;
+
+-- name: UpsertUserTransactionLimit :one
+INSERT INTO transaction_limits (user_id, asset_id, transaction_type, per_transaction, daily, monthly)
+VALUES (sqlc.arg(user_id)::uuid, sqlc.arg(asset_id), sqlc.arg(transaction_type), sqlc.arg(per_transaction), sqlc.arg(daily), sqlc.arg(monthly))
+ON CONFLICT (user_id, asset_id, transaction_type) WHERE user_id IS NOT NULL
+DO UPDATE SET per_transaction = EXCLUDED.per_transaction,
+  daily = EXCLUDED.daily,
+  monthly = EXCLUDED.monthly,
+  updated_at = now()
+RETURNING *;
\ No newline at end of file
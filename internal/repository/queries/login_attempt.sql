@@ -0,0 +1,30 @@
+-- name: ClearLoginAttempts :exec
+DELETE FROM login_attempts
+WHERE scope = $1 AND subject = $2;
+
+-- name: LockLogin :exec
+UPDATE login_attempts
+SET locked_until = $3
+WHERE scope = $1 AND subject = $2;
+
+-- name: ReleaseLoginAttempt :one
+UPDATE login_attempts
+SET failures = GREATEST(failures - 1, 0),
+    locked_until = CASE WHEN locked_until = sqlc.arg(locked_until) THEN NULL ELSE locked_until END
+WHERE scope = sqlc.arg(scope) AND subject = sqlc.arg(subject)
+RETURNING failures;
+
+-- name: ReserveLoginAttempt :one
+INSERT INTO login_attempts (scope, subject, failures, last_failed_at)
+VALUES (sqlc.arg(scope), sqlc.arg(subject), 1, now())
+ON CONFLICT (scope, subject) DO UPDATE
+SET failures = CASE
+      WHEN login_attempts.locked_until > now() THEN login_attempts.failures
+      WHEN login_attempts.last_failed_at < sqlc.arg(reset_before)::timestamptz THEN 1
+      ELSE login_attempts.failures + 1
+    END,
+    last_failed_at = CASE
+      WHEN login_attempts.locked_until > now() THEN login_attempts.last_failed_at
+      ELSE now()
+    END
+RETURNING failures, locked_until;
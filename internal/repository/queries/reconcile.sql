@@ -0,0 +1,23 @@
+-- name: FindUnbalancedTransactions :many
+SELECT l.transaction_id, t.type, w.asset_id, SUM(l.amount)::bigint AS net
+FROM ledgers l
+JOIN transactions t ON t.id = l.transaction_id
+JOIN wallets w ON w.id = l.wallet_id
+GROUP BY l.transaction_id, t.type, w.asset_id
+HAVING SUM(l.amount) <> 0
+ORDER BY l.transaction_id;
+
+-- name: FindOrphanTransactions :many
+SELECT t.id, t.type, t.created_at
+FROM transactions t
+WHERE NOT EXISTS (SELECT 1 FROM ledgers l WHERE l.transaction_id = t.id)
+ORDER BY t.created_at;
+
+-- name: FindNegativeUserBalances :many
+SELECT w.id AS wallet_id, w.owner_id, w.asset_id, SUM(l.amount)::bigint AS balance
+FROM wallets w
+JOIN ledgers l ON l.wallet_id = w.id
+WHERE w.owner_type = 'USER'
+GROUP BY w.id, w.owner_id, w.asset_id
+HAVING SUM(l.amount) < 0
+ORDER BY w.id;
\ No newline at end of file
@@ -0,0 +1,34 @@
+-- name: CreateSession :one
+INSERT INTO sessions (id, user_id, refresh_token_hash, user_agent, ip_address, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetSessionById :one
+SELECT *
+FROM sessions
+WHERE id = $1;
+
+-- name: LockSessionByToken :one
+SELECT *
+FROM sessions
+WHERE refresh_token_hash = $1 OR previous_token_hash = $1
+FOR UPDATE;
+
+-- name: RevokeSession :execrows
+UPDATE sessions
+SET revoked_at = now()
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :execrows
+UPDATE sessions
+SET revoked_at = now()
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: RotateSession :one
+UPDATE sessions
+SET previous_token_hash = refresh_token_hash,
+    refresh_token_hash = $2,
+    expires_at = $3,
+    last_used_at = now()
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -3,11 +3,28 @@ INSERT INTO transactions (id, type)
 VALUES ($1, $2)
 RETURNING *;
 
+-- name: CreateChildTxn :one
+INSERT INTO transactions (id, type, parent_transaction_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
 -- name: GetTransactionById :one
 SELECT *
 FROM transactions
 WHERE id = $1;
 
+-- name: LockTransaction :one
+SELECT *
+FROM transactions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: AddRefundedAmount :one
+UPDATE transactions
+SET refunded_amount = refunded_amount + $2
+WHERE id = $1
+RETURNING *;
+
 -- name: GetTransactionByType :many
 SELECT *
 FROM transactions
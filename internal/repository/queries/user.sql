@@ -1,6 +1,6 @@
 -- name: CreateUser :one
-INSERT INTO users(username, password)
-VALUES ($1, $2)
+INSERT INTO users(username, password, referred_by)
+VALUES ($1, $2, $3)
 RETURNING *;
 
 -- name: GetUserById :one
@@ -11,4 +11,21 @@ WHERE id = $1;
 -- name: GetUserByUsername :one
 SELECT *
 FROM users
-WHERE username = $1;
\ No newline at end of file
+WHERE username = $1;
+
+-- name: UpdateUserLimitTier :one
+UPDATE users
+SET limit_tier = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $2
+WHERE id = $1;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
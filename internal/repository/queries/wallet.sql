@@ -3,6 +3,11 @@ INSERT INTO wallets (owner_type, owner_id, asset_id)
 VALUES ($1, $2, $3)
 RETURNING *;
 
+-- name: EnsureWallet :exec
+INSERT INTO wallets (owner_type, owner_id, asset_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (owner_type, owner_id, asset_id) DO NOTHING;
+
 -- name: GetWalletById :one
 SELECT *
 FROM wallets
@@ -11,15 +16,54 @@ WHERE id = $1;
 -- name: GetWalletByOwner :one
 SELECT *
 FROM wallets
-WHERE owner_id = $1;
+WHERE owner_type = 'USER' AND owner_id = $1 AND asset_id = $2;
+
+-- name: ListWalletsByOwner :many
+SELECT *
+FROM wallets
+WHERE owner_type = 'USER' AND owner_id = $1
+ORDER BY created_at;
 
 -- name: GetSystemWallet :one
 SELECT id
 FROM wallets
-WHERE owner_type = 'SYSTEM';
+WHERE owner_type = 'SYSTEM' AND asset_id = $1;
 
--- name: LockWallet :one
+-- name: GetPromotionWallet :one
+SELECT id
+FROM wallets
+WHERE owner_type = 'PROMOTION' AND asset_id = $1;
+
+-- name: GetTreasuryWallet :one
 SELECT id
 FROM wallets
-WHERE owner_id = $1
-FOR UPDATE;
\ No newline at end of file
+WHERE owner_type = 'TREASURY' AND asset_id = $1;
+
+-- name: LockWallet :one
+SELECT *
+FROM wallets
+WHERE owner_type = 'USER' AND owner_id = $1 AND asset_id = $2
+FOR UPDATE;
+
+-- name: LockWalletById :one
+SELECT *
+FROM wallets
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateWalletStatus :one
+UPDATE wallets
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateWalletStatusChange :one
+INSERT INTO wallet_status_changes (wallet_id, from_status, to_status, reason, changed_by, sweep_transaction_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListWalletStatusChanges :many
+SELECT *
+FROM wallet_status_changes
+WHERE wallet_id = $1
+ORDER BY created_at DESC;
\ No newline at end of file
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -51,3 +52,44 @@ func (r *Repository) WithTransaction(ctx context.Context, fn func(*Queries) erro
 
 	return nil
 }
+
+// WithSnapshot executes a function within a read-only REPEATABLE READ
+// transaction, so every query in it sees the same snapshot of the database
+func (r *Repository) WithSnapshot(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(r.queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// PostLedger writes a ledger entry and applies it to the wallet's cached
+// balance. Services must use it instead of CreateLedger so the cache never
+// drifts from the ledger.
+//
+// A user wallet's row in wallet_balances is updated before the entry is
+// inserted: its row lock is then held while the entry is numbered, which
+// lets a checkpoint that locks the same row rely on every lower ledger seq of
+// the wallet being committed. Wallets not owned by a user, such as the
+// system wallet, take a leg of many concurrent requests, so their change is
+// appended to wallet_balance_deltas instead and no row is locked.
+func (q *Queries) PostLedger(ctx context.Context, arg CreateLedgerParams) (Ledger, error) {
+	err := q.ApplyWalletBalance(ctx, ApplyWalletBalanceParams{
+		WalletID: arg.WalletID,
+		Balance:  arg.Amount,
+	})
+	if err != nil {
+		return Ledger{}, err
+	}
+
+	return q.CreateLedger(ctx, arg)
+}
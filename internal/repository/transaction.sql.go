@@ -9,12 +9,63 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addRefundedAmount = `-- name: AddRefundedAmount :one
+UPDATE transactions
+SET refunded_amount = refunded_amount + $2
+WHERE id = $1
+RETURNING id, type, created_at, parent_transaction_id, refunded_amount
+`
+
+type AddRefundedAmountParams struct {
+	ID             uuid.UUID `json:"id"`
+	RefundedAmount int64     `json:"refunded_amount"`
+}
+
+func (q *Queries) AddRefundedAmount(ctx context.Context, arg AddRefundedAmountParams) (Transaction, error) {
+	row := q.db.QueryRow(ctx, addRefundedAmount, arg.ID, arg.RefundedAmount)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.CreatedAt,
+		&i.ParentTransactionID,
+		&i.RefundedAmount,
+	)
+	return i, err
+}
+
+const createChildTxn = `-- name: CreateChildTxn :one
+INSERT INTO transactions (id, type, parent_transaction_id)
+VALUES ($1, $2, $3)
+RETURNING id, type, created_at, parent_transaction_id, refunded_amount
+`
+
+type CreateChildTxnParams struct {
+	ID                  uuid.UUID       `json:"id"`
+	Type                TransactionType `json:"type"`
+	ParentTransactionID pgtype.UUID     `json:"parent_transaction_id"`
+}
+
+func (q *Queries) CreateChildTxn(ctx context.Context, arg CreateChildTxnParams) (Transaction, error) {
+	row := q.db.QueryRow(ctx, createChildTxn, arg.ID, arg.Type, arg.ParentTransactionID)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.CreatedAt,
+		&i.ParentTransactionID,
+		&i.RefundedAmount,
+	)
+	return i, err
+}
+
 const createTxn = `-- name: CreateTxn :one
 INSERT INTO transactions (id, type)
 VALUES ($1, $2)
-RETURNING id, type, created_at
+RETURNING id, type, created_at, parent_transaction_id, refunded_amount
 `
 
 type CreateTxnParams struct {
@@ -25,12 +76,18 @@ type CreateTxnParams struct {
 func (q *Queries) CreateTxn(ctx context.Context, arg CreateTxnParams) (Transaction, error) {
 	row := q.db.QueryRow(ctx, createTxn, arg.ID, arg.Type)
 	var i Transaction
-	err := row.Scan(&i.ID, &i.Type, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.CreatedAt,
+		&i.ParentTransactionID,
+		&i.RefundedAmount,
+	)
 	return i, err
 }
 
 const getTransactionById = `-- name: GetTransactionById :one
-SELECT id, type, created_at
+SELECT id, type, created_at, parent_transaction_id, refunded_amount
 FROM transactions
 WHERE id = $1
 `
@@ -38,12 +95,18 @@ WHERE id = $1
 func (q *Queries) GetTransactionById(ctx context.Context, id uuid.UUID) (Transaction, error) {
 	row := q.db.QueryRow(ctx, getTransactionById, id)
 	var i Transaction
-	err := row.Scan(&i.ID, &i.Type, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.CreatedAt,
+		&i.ParentTransactionID,
+		&i.RefundedAmount,
+	)
 	return i, err
 }
 
 const getTransactionByType = `-- name: GetTransactionByType :many
-SELECT id, type, created_at
+SELECT id, type, created_at, parent_transaction_id, refunded_amount
 FROM transactions
 WHERE type = $1
 ORDER BY
@@ -75,7 +138,13 @@ func (q *Queries) GetTransactionByType(ctx context.Context, arg GetTransactionBy
 	items := []Transaction{}
 	for rows.Next() {
 		var i Transaction
-		if err := rows.Scan(&i.ID, &i.Type, &i.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.CreatedAt,
+			&i.ParentTransactionID,
+			&i.RefundedAmount,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -85,3 +154,23 @@ func (q *Queries) GetTransactionByType(ctx context.Context, arg GetTransactionBy
 	}
 	return items, nil
 }
+
+const lockTransaction = `-- name: LockTransaction :one
+SELECT id, type, created_at, parent_transaction_id, refunded_amount
+FROM transactions
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockTransaction(ctx context.Context, id uuid.UUID) (Transaction, error) {
+	row := q.db.QueryRow(ctx, lockTransaction, id)
+	var i Transaction
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.CreatedAt,
+		&i.ParentTransactionID,
+		&i.RefundedAmount,
+	)
+	return i, err
+}
@@ -9,33 +9,38 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO users(username, password)
-VALUES ($1, $2)
-RETURNING id, username, password, created_at
+INSERT INTO users(username, password, referred_by)
+VALUES ($1, $2, $3)
+RETURNING id, username, password, created_at, referred_by, role, limit_tier
 `
 
 type CreateUserParams struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username   string      `json:"username"`
+	Password   string      `json:"password"`
+	ReferredBy pgtype.UUID `json:"referred_by"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
-	row := q.db.QueryRow(ctx, createUser, arg.Username, arg.Password)
+	row := q.db.QueryRow(ctx, createUser, arg.Username, arg.Password, arg.ReferredBy)
 	var i User
 	err := row.Scan(
 		&i.ID,
 		&i.Username,
 		&i.Password,
 		&i.CreatedAt,
+		&i.ReferredBy,
+		&i.Role,
+		&i.LimitTier,
 	)
 	return i, err
 }
 
 const getUserById = `-- name: GetUserById :one
-SELECT id, username, password, created_at
+SELECT id, username, password, created_at, referred_by, role, limit_tier
 FROM users
 WHERE id = $1
 `
@@ -48,12 +53,15 @@ func (q *Queries) GetUserById(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Username,
 		&i.Password,
 		&i.CreatedAt,
+		&i.ReferredBy,
+		&i.Role,
+		&i.LimitTier,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, password, created_at
+SELECT id, username, password, created_at, referred_by, role, limit_tier
 FROM users
 WHERE username = $1
 `
@@ -66,6 +74,79 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.Username,
 		&i.Password,
 		&i.CreatedAt,
+		&i.ReferredBy,
+		&i.Role,
+		&i.LimitTier,
+	)
+	return i, err
+}
+
+const updateUserLimitTier = `-- name: UpdateUserLimitTier :one
+UPDATE users
+SET limit_tier = $2
+WHERE id = $1
+RETURNING id, username, password, created_at, referred_by, role, limit_tier
+`
+
+type UpdateUserLimitTierParams struct {
+	ID        uuid.UUID `json:"id"`
+	LimitTier LimitTier `json:"limit_tier"`
+}
+
+func (q *Queries) UpdateUserLimitTier(ctx context.Context, arg UpdateUserLimitTierParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserLimitTier, arg.ID, arg.LimitTier)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.CreatedAt,
+		&i.ReferredBy,
+		&i.Role,
+		&i.LimitTier,
+	)
+	return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $2
+WHERE id = $1
+`
+
+type UpdateUserPasswordParams struct {
+	ID       uuid.UUID `json:"id"`
+	Password string    `json:"password"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.Password)
+	return err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2
+WHERE id = $1
+RETURNING id, username, password, created_at, referred_by, role, limit_tier
+`
+
+type UpdateUserRoleParams struct {
+	ID   uuid.UUID `json:"id"`
+	Role UserRole  `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, arg.ID, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.CreatedAt,
+		&i.ReferredBy,
+		&i.Role,
+		&i.LimitTier,
 	)
 	return i, err
 }
@@ -9,12 +9,13 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createWallet = `-- name: CreateWallet :one
 INSERT INTO wallets (owner_type, owner_id, asset_id)
 VALUES ($1, $2, $3)
-RETURNING id, owner_type, owner_id, asset_id, created_at
+RETURNING id, owner_type, owner_id, asset_id, created_at, status
 `
 
 type CreateWalletParams struct {
@@ -32,25 +33,107 @@ func (q *Queries) CreateWallet(ctx context.Context, arg CreateWalletParams) (Wal
 		&i.OwnerID,
 		&i.AssetID,
 		&i.CreatedAt,
+		&i.Status,
 	)
 	return i, err
 }
 
+const createWalletStatusChange = `-- name: CreateWalletStatusChange :one
+INSERT INTO wallet_status_changes (wallet_id, from_status, to_status, reason, changed_by, sweep_transaction_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, wallet_id, from_status, to_status, reason, changed_by, sweep_transaction_id, created_at
+`
+
+type CreateWalletStatusChangeParams struct {
+	WalletID           uuid.UUID    `json:"wallet_id"`
+	FromStatus         WalletStatus `json:"from_status"`
+	ToStatus           WalletStatus `json:"to_status"`
+	Reason             string       `json:"reason"`
+	ChangedBy          pgtype.UUID  `json:"changed_by"`
+	SweepTransactionID pgtype.UUID  `json:"sweep_transaction_id"`
+}
+
+func (q *Queries) CreateWalletStatusChange(ctx context.Context, arg CreateWalletStatusChangeParams) (WalletStatusChange, error) {
+	row := q.db.QueryRow(ctx, createWalletStatusChange,
+		arg.WalletID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+		arg.ChangedBy,
+		arg.SweepTransactionID,
+	)
+	var i WalletStatusChange
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Reason,
+		&i.ChangedBy,
+		&i.SweepTransactionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const ensureWallet = `-- name: EnsureWallet :exec
+INSERT INTO wallets (owner_type, owner_id, asset_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (owner_type, owner_id, asset_id) DO NOTHING
+`
+
+type EnsureWalletParams struct {
+	OwnerType WalletOwnerType `json:"owner_type"`
+	OwnerID   uuid.UUID       `json:"owner_id"`
+	AssetID   uuid.UUID       `json:"asset_id"`
+}
+
+func (q *Queries) EnsureWallet(ctx context.Context, arg EnsureWalletParams) error {
+	_, err := q.db.Exec(ctx, ensureWallet, arg.OwnerType, arg.OwnerID, arg.AssetID)
+	return err
+}
+
+const getPromotionWallet = `-- name: GetPromotionWallet :one
+SELECT id
+FROM wallets
+WHERE owner_type = 'PROMOTION' AND asset_id = $1
+`
+
+func (q *Queries) GetPromotionWallet(ctx context.Context, assetID uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getPromotionWallet, assetID)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getSystemWallet = `-- name: GetSystemWallet :one
 SELECT id
 FROM wallets
-WHERE owner_type = 'SYSTEM'
+WHERE owner_type = 'SYSTEM' AND asset_id = $1
 `
 
-func (q *Queries) GetSystemWallet(ctx context.Context) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, getSystemWallet)
+func (q *Queries) GetSystemWallet(ctx context.Context, assetID uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getSystemWallet, assetID)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getTreasuryWallet = `-- name: GetTreasuryWallet :one
+SELECT id
+FROM wallets
+WHERE owner_type = 'TREASURY' AND asset_id = $1
+`
+
+func (q *Queries) GetTreasuryWallet(ctx context.Context, assetID uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getTreasuryWallet, assetID)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
 }
 
 const getWalletById = `-- name: GetWalletById :one
-SELECT id, owner_type, owner_id, asset_id, created_at
+SELECT id, owner_type, owner_id, asset_id, created_at, status
 FROM wallets
 WHERE id = $1
 `
@@ -64,18 +147,24 @@ func (q *Queries) GetWalletById(ctx context.Context, id uuid.UUID) (Wallet, erro
 		&i.OwnerID,
 		&i.AssetID,
 		&i.CreatedAt,
+		&i.Status,
 	)
 	return i, err
 }
 
 const getWalletByOwner = `-- name: GetWalletByOwner :one
-SELECT id, owner_type, owner_id, asset_id, created_at
+SELECT id, owner_type, owner_id, asset_id, created_at, status
 FROM wallets
-WHERE owner_id = $1
+WHERE owner_type = 'USER' AND owner_id = $1 AND asset_id = $2
 `
 
-func (q *Queries) GetWalletByOwner(ctx context.Context, ownerID uuid.UUID) (Wallet, error) {
-	row := q.db.QueryRow(ctx, getWalletByOwner, ownerID)
+type GetWalletByOwnerParams struct {
+	OwnerID uuid.UUID `json:"owner_id"`
+	AssetID uuid.UUID `json:"asset_id"`
+}
+
+func (q *Queries) GetWalletByOwner(ctx context.Context, arg GetWalletByOwnerParams) (Wallet, error) {
+	row := q.db.QueryRow(ctx, getWalletByOwner, arg.OwnerID, arg.AssetID)
 	var i Wallet
 	err := row.Scan(
 		&i.ID,
@@ -83,20 +172,150 @@ func (q *Queries) GetWalletByOwner(ctx context.Context, ownerID uuid.UUID) (Wall
 		&i.OwnerID,
 		&i.AssetID,
 		&i.CreatedAt,
+		&i.Status,
 	)
 	return i, err
 }
 
+const listWalletStatusChanges = `-- name: ListWalletStatusChanges :many
+SELECT id, wallet_id, from_status, to_status, reason, changed_by, sweep_transaction_id, created_at
+FROM wallet_status_changes
+WHERE wallet_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListWalletStatusChanges(ctx context.Context, walletID uuid.UUID) ([]WalletStatusChange, error) {
+	rows, err := q.db.Query(ctx, listWalletStatusChanges, walletID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WalletStatusChange
+	for rows.Next() {
+		var i WalletStatusChange
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.ChangedBy,
+			&i.SweepTransactionID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWalletsByOwner = `-- name: ListWalletsByOwner :many
+SELECT id, owner_type, owner_id, asset_id, created_at, status
+FROM wallets
+WHERE owner_type = 'USER' AND owner_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListWalletsByOwner(ctx context.Context, ownerID uuid.UUID) ([]Wallet, error) {
+	rows, err := q.db.Query(ctx, listWalletsByOwner, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Wallet
+	for rows.Next() {
+		var i Wallet
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerType,
+			&i.OwnerID,
+			&i.AssetID,
+			&i.CreatedAt,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const lockWallet = `-- name: LockWallet :one
-SELECT id
+SELECT id, owner_type, owner_id, asset_id, created_at, status
 FROM wallets
-WHERE owner_id = $1
+WHERE owner_type = 'USER' AND owner_id = $1 AND asset_id = $2
 FOR UPDATE
 `
 
-func (q *Queries) LockWallet(ctx context.Context, ownerID uuid.UUID) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, lockWallet, ownerID)
-	var id uuid.UUID
-	err := row.Scan(&id)
-	return id, err
+type LockWalletParams struct {
+	OwnerID uuid.UUID `json:"owner_id"`
+	AssetID uuid.UUID `json:"asset_id"`
+}
+
+func (q *Queries) LockWallet(ctx context.Context, arg LockWalletParams) (Wallet, error) {
+	row := q.db.QueryRow(ctx, lockWallet, arg.OwnerID, arg.AssetID)
+	var i Wallet
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerType,
+		&i.OwnerID,
+		&i.AssetID,
+		&i.CreatedAt,
+		&i.Status,
+	)
+	return i, err
+}
+
+const lockWalletById = `-- name: LockWalletById :one
+SELECT id, owner_type, owner_id, asset_id, created_at, status
+FROM wallets
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockWalletById(ctx context.Context, id uuid.UUID) (Wallet, error) {
+	row := q.db.QueryRow(ctx, lockWalletById, id)
+	var i Wallet
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerType,
+		&i.OwnerID,
+		&i.AssetID,
+		&i.CreatedAt,
+		&i.Status,
+	)
+	return i, err
+}
+
+const updateWalletStatus = `-- name: UpdateWalletStatus :one
+UPDATE wallets
+SET status = $2
+WHERE id = $1
+RETURNING id, owner_type, owner_id, asset_id, created_at, status
+`
+
+type UpdateWalletStatusParams struct {
+	ID     uuid.UUID    `json:"id"`
+	Status WalletStatus `json:"status"`
+}
+
+func (q *Queries) UpdateWalletStatus(ctx context.Context, arg UpdateWalletStatusParams) (Wallet, error) {
+	row := q.db.QueryRow(ctx, updateWalletStatus, arg.ID, arg.Status)
+	var i Wallet
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerType,
+		&i.OwnerID,
+		&i.AssetID,
+		&i.CreatedAt,
+		&i.Status,
+	)
+	return i, err
 }
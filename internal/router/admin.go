@@ -0,0 +1,67 @@
+package router
+
+import (
+	"github.com/AdityaTote/wallet-service/internal/handler"
+	"github.com/AdityaTote/wallet-service/internal/middleware"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+func adminRouter(h handler.Handlers, authMiddleware *middleware.AuthMiddleware, csrfMiddleware *middleware.CSRFMiddleware, rbacMiddleware *middleware.RBACMiddleware) *chi.Mux {
+	r := chi.NewRouter()
+
+	// every admin route needs a signed-in caller whose role grants the
+	// route's permission
+	r.Use(authMiddleware.Middleware)
+	r.Use(csrfMiddleware.Middleware)
+
+	r.With(rbacMiddleware.Require(models.PermLedgerReconcile)).Get("/reconcile", h.Admin().Reconcile)
+
+	r.Route("/users", func(r chi.Router) {
+		r.With(rbacMiddleware.Require(models.PermUsersRead)).Get("/{user}", h.Admin().GetUser)
+		r.With(rbacMiddleware.Require(models.PermRolesManage)).Put("/{id}/role", h.Admin().SetUserRole)
+
+		r.Route("/{id}/limits", func(r chi.Router) {
+			r.With(rbacMiddleware.Require(models.PermUsersRead)).Get("/", h.Admin().GetUserLimits)
+			r.With(rbacMiddleware.Require(models.PermLimitsManage)).Put("/tier", h.Admin().SetUserLimitTier)
+			r.With(rbacMiddleware.Require(models.PermLimitsManage)).Put("/transactions", h.Admin().SetUserTransactionLimit)
+			r.With(rbacMiddleware.Require(models.PermLimitsManage)).Delete("/transactions/{asset}/{type}", h.Admin().DeleteUserTransactionLimit)
+			r.With(rbacMiddleware.Require(models.PermLimitsManage)).Put("/balance", h.Admin().SetUserBalanceLimit)
+			r.With(rbacMiddleware.Require(models.PermLimitsManage)).Delete("/balance/{asset}", h.Admin().DeleteUserBalanceLimit)
+		})
+	})
+
+	r.Route("/wallets/{id}", func(r chi.Router) {
+		r.With(rbacMiddleware.Require(models.PermWalletsRead)).Get("/", h.Admin().GetWallet)
+		r.With(rbacMiddleware.Require(models.PermWalletsRead)).Get("/transactions", h.Admin().GetWalletTransactions)
+		r.With(rbacMiddleware.Require(models.PermWalletsRead)).Get("/status-history", h.Admin().GetWalletStatusHistory)
+		r.With(rbacMiddleware.Require(models.PermWalletsFreeze)).Post("/status", h.Admin().SetWalletStatus)
+		r.With(rbacMiddleware.Require(models.PermWalletsClose)).Post("/close", h.Admin().CloseWallet)
+	})
+
+	r.Route("/assets", func(r chi.Router) {
+		r.With(rbacMiddleware.Require(models.PermAssetsRead)).Get("/", h.Admin().ListAssets)
+		r.With(rbacMiddleware.Require(models.PermAssetsManage)).Post("/", h.Admin().CreateAsset)
+		r.With(rbacMiddleware.Require(models.PermAssetsRead)).Get("/{asset}", h.Admin().GetAsset)
+		r.With(rbacMiddleware.Require(models.PermAssetsManage)).Patch("/{asset}", h.Admin().UpdateAsset)
+		r.With(rbacMiddleware.Require(models.PermAssetsManage)).Delete("/{asset}", h.Admin().DeactivateAsset)
+	})
+
+	r.Route("/fx-rates", func(r chi.Router) {
+		r.With(rbacMiddleware.Require(models.PermFxRead)).Get("/", h.Admin().ListFxRates)
+		r.With(rbacMiddleware.Require(models.PermFxManage)).Post("/", h.Admin().CreateFxRate)
+		r.With(rbacMiddleware.Require(models.PermFxRead)).Get("/{id}", h.Admin().GetFxRate)
+		r.With(rbacMiddleware.Require(models.PermFxManage)).Delete("/{id}", h.Admin().EndFxRate)
+	})
+
+	r.Route("/campaigns", func(r chi.Router) {
+		r.With(rbacMiddleware.Require(models.PermCampaignsRead)).Get("/", h.Admin().ListCampaigns)
+		r.With(rbacMiddleware.Require(models.PermCampaignsManage)).Post("/", h.Admin().CreateCampaign)
+		r.With(rbacMiddleware.Require(models.PermCampaignsRead)).Get("/{id}", h.Admin().GetCampaign)
+		r.With(rbacMiddleware.Require(models.PermCampaignsManage)).Patch("/{id}", h.Admin().UpdateCampaign)
+		r.With(rbacMiddleware.Require(models.PermCampaignsManage)).Delete("/{id}", h.Admin().EndCampaign)
+		r.With(rbacMiddleware.Require(models.PermCampaignsManage)).Post("/{id}/grant", h.Admin().GrantBonus)
+	})
+
+	return r
+}
@@ -2,14 +2,25 @@ package router
 
 import (
 	"github.com/AdityaTote/wallet-service/internal/handler"
+	"github.com/AdityaTote/wallet-service/internal/middleware"
 	"github.com/go-chi/chi/v5"
 )
 
-func authRouter(h handler.Handlers) *chi.Mux {
+func authRouter(h handler.Handlers, authMiddleware *middleware.AuthMiddleware, csrfMiddleware *middleware.CSRFMiddleware) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Post("/signup", h.Auth().Signup)
 	r.Post("/signin", h.Auth().Signin)
+	r.Post("/refresh", h.Auth().Refresh)
+
+	// these act on the session of the access token
+	r.Group(func(r chi.Router) {
+		r.Use(authMiddleware.Middleware)
+		r.Use(csrfMiddleware.Middleware)
+		r.Post("/logout", h.Auth().Logout)
+		r.Post("/logout-all", h.Auth().LogoutAll)
+		r.Post("/password", h.Auth().ChangePassword)
+	})
 
 	return r
 }
\ No newline at end of file
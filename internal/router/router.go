@@ -12,18 +12,23 @@ import (
 func New(h handler.Handlers, srv *server.Server, repo *repository.Repository, log zerolog.Logger) *chi.Mux {
 	// initialize auth middleware
 	authMiddleware := middleware.NewAuthMiddleware(srv, repo, log)
+	csrfMiddleware := middleware.NewCSRFMiddleware(log)
+	rbacMiddleware := middleware.NewRBACMiddleware(log)
 	router := chi.NewRouter()
-	router.Mount("/api", apiRoutes(h, authMiddleware))
+	router.Get("/.well-known/jwks.json", h.Keys().JWKS)
+	router.Mount("/api", apiRoutes(h, authMiddleware, csrfMiddleware, rbacMiddleware))
 	return router
 }
 
-func apiRoutes(h handler.Handlers, authMiddleware *middleware.AuthMiddleware) *chi.Mux {
+func apiRoutes(h handler.Handlers, authMiddleware *middleware.AuthMiddleware, csrfMiddleware *middleware.CSRFMiddleware, rbacMiddleware *middleware.RBACMiddleware) *chi.Mux {
 	r := chi.NewRouter()
 	
 	// routes
 	r.Get("/health", h.Health().CheckHealth)
-	r.Mount("/auth", authRouter(h))
-	r.Mount("/wallet", walletRouter(h, authMiddleware))
+	r.Get("/ready", h.Health().CheckReady)
+	r.Mount("/auth", authRouter(h, authMiddleware, csrfMiddleware))
+	r.Mount("/wallet", walletRouter(h, authMiddleware, csrfMiddleware, rbacMiddleware))
+	r.Mount("/admin", adminRouter(h, authMiddleware, csrfMiddleware, rbacMiddleware))
 
 
 	return r
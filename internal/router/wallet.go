@@ -3,18 +3,37 @@ package router
 import (
 	"github.com/AdityaTote/wallet-service/internal/handler"
 	"github.com/AdityaTote/wallet-service/internal/middleware"
+	"github.com/AdityaTote/wallet-service/internal/models"
 	"github.com/go-chi/chi/v5"
 )
 
-func walletRouter(h handler.Handlers, authMiddleware *middleware.AuthMiddleware) *chi.Mux {
+func walletRouter(h handler.Handlers, authMiddleware *middleware.AuthMiddleware, csrfMiddleware *middleware.CSRFMiddleware, rbacMiddleware *middleware.RBACMiddleware) *chi.Mux {
 	r := chi.NewRouter()
 
 	// apply auth middleware to all wallet routes
 	r.Use(authMiddleware.Middleware)
+	// cookie-authenticated POSTs must carry the CSRF token
+	r.Use(csrfMiddleware.Middleware)
 
 	r.Get("/balance", h.Wallet().GetBalance)
+	r.Get("/transactions", h.Wallet().GetTransactions)
+	r.With(rbacMiddleware.Require(models.PermTransactionsRefund)).Post("/transactions/{id}/refund", h.Wallet().Refund)
 	r.Post("/topup", h.Wallet().TopUp)
 	r.Post("/spend", h.Wallet().Spend)
+	r.Post("/transfer", h.Wallet().Transfer)
+
+	r.Route("/holds", func(r chi.Router) {
+		r.Post("/", h.Wallet().Authorize)
+		r.Get("/{id}", h.Wallet().GetHold)
+		r.Post("/{id}/capture", h.Wallet().Capture)
+		r.Post("/{id}/void", h.Wallet().Void)
+	})
+
+	r.Route("/convert", func(r chi.Router) {
+		r.Post("/", h.Wallet().Convert)
+		r.Post("/quotes", h.Wallet().Quote)
+		r.Get("/quotes/{id}", h.Wallet().GetQuote)
+	})
 
 	return r
 }
\ No newline at end of file
@@ -3,7 +3,9 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/AdityaTote/wallet-service/internal/config"
 	"github.com/AdityaTote/wallet-service/internal/database"
@@ -13,6 +15,10 @@ type Server struct {
 	Config *config.Config
 	Database *database.Database
 	httpServer *http.Server
+	// ready is reported by the readiness probe; it is cleared as soon as
+	// shutdown begins so load balancers stop routing new requests
+	ready atomic.Bool
+	onDrained []func()
 }
 
 func New(cfg *config.Config, db *database.Database) (*Server) {
@@ -33,17 +39,54 @@ func (s *Server) Start() error {
 	if s.httpServer == nil {
 		return fmt.Errorf("Http server not initiated")
 	}
-	return s.httpServer.ListenAndServe()
+
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+
+	s.ready.Store(true)
+	return s.httpServer.Serve(listener)
+}
+
+// Ready reports whether the server accepts new traffic.
+func (s *Server) Ready() bool {
+	return s.ready.Load()
+}
+
+// SetReady changes what the readiness probe reports.
+func (s *Server) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+// OnDrained registers fn to run once the HTTP server has drained and before
+// the database pool is closed.
+func (s *Server) OnDrained(fn func()) {
+	s.onDrained = append(s.onDrained, fn)
 }
 
+// Shutdown stops the server in order: it stops accepting connections and waits
+// for in-flight requests, and with them their database transactions, to
+// finish. Requests still running when ctx expires are cut off. The database
+// pool is closed last.
 func (s *Server) Shutdown(ctx context.Context) error {
-	if err := s.Database.Close(); err != nil {
-		return fmt.Errorf("failed to close database: %w", err)
+	s.ready.Store(false)
+
+	var drainErr error
+	if s.httpServer != nil {
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			drainErr = fmt.Errorf("failed to drain http server: %w", err)
+			s.httpServer.Close()
+		}
+	}
+
+	for _, fn := range s.onDrained {
+		fn()
 	}
 
-	if err := s.httpServer.Close(); err != nil {
-		return fmt.Errorf("failed to close http server: %w", err)
+	if err := s.Database.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
 	}
 
-	return nil
-}
\ No newline at end of file
+	return drainErr
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+)
+
+// AdminService backs the back-office views of /api/admin. Unlike the wallet
+// service it addresses any user's wallets by id; the routes decide who may
+// call it.
+type AdminService interface {
+	GetUser(ref string) (*models.AdminUserResponse, error)
+	SetRole(userId uuid.UUID, role models.Role) (*models.AdminUserResponse, error)
+	GetWallet(walletId uuid.UUID) (*models.AdminWalletResponse, error)
+	WalletHistory(walletId uuid.UUID, input *models.TransactionHistoryRequest) (*models.TransactionHistoryResponse, error)
+	SetWalletStatus(*models.WalletStatusParams) (*models.WalletStatusResponse, error)
+	WalletStatusHistory(walletId uuid.UUID) ([]models.WalletStatusChangeResponse, error)
+}
+
+type adminService struct {
+	ctx context.Context
+	log zerolog.Logger
+	repo repository.Repository
+}
+
+// GetUser finds a user by id or, failing that, by username, together with
+// their wallets and balances.
+func (a *adminService) GetUser(ref string) (*models.AdminUserResponse, error) {
+	query := a.repo.Queries()
+
+	var user repository.User
+	var err error
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		user, err = query.GetUserById(a.ctx, id)
+	} else {
+		user, err = query.GetUserByUsername(a.ctx, ref)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrUserNotFound
+		}
+		a.log.Error().Err(err).Msg("failed to get user")
+		return nil, models.NewAppError(err, "failed to get user", 500)
+	}
+
+	return a.respondUser(query, user)
+}
+
+// SetRole changes a user's role. The auth middleware reads the role from the
+// user row, so the change applies to open sessions from their next request.
+func (a *adminService) SetRole(userId uuid.UUID, role models.Role) (*models.AdminUserResponse, error) {
+	if !role.Valid() {
+		return nil, models.ErrInvalidRole
+	}
+
+	query := a.repo.Queries()
+
+	user, err := query.UpdateUserRole(a.ctx, repository.UpdateUserRoleParams{
+		ID: userId,
+		Role: repository.UserRole(role),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrUserNotFound
+		}
+		a.log.Error().Err(err).Msg("failed to update user role")
+		return nil, models.NewAppError(err, "failed to update role", 500)
+	}
+
+	a.log.Info().Str("user_id", user.ID.String()).Str("role", string(user.Role)).Msg("user role changed")
+
+	return a.respondUser(query, user)
+}
+
+func (a *adminService) GetWallet(walletId uuid.UUID) (*models.AdminWalletResponse, error) {
+	query := a.repo.Queries()
+
+	wallet, err := a.userWallet(query, walletId)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.respondWallet(query, wallet)
+}
+
+func (a *adminService) WalletHistory(walletId uuid.UUID, input *models.TransactionHistoryRequest) (*models.TransactionHistoryResponse, error) {
+	query := a.repo.Queries()
+
+	wallet, err := a.userWallet(query, walletId)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := query.GetAssetById(a.ctx, wallet.AssetID)
+	if err != nil {
+		a.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "failed to retrieve transactions", 500)
+	}
+
+	return a.wallets().history(query, walletId, asset.Decimals, input)
+}
+
+// SetWalletStatus moves a wallet between ACTIVE, FROZEN_DEBIT, FROZEN_ALL
+// and CLOSED, recording who did it and why. The wallet's row lock is held
+// throughout, so an operation that already holds it finishes first and later
+// ones see the new state. Closing is final: the wallet must have no active
+// holds, and its balance is swept to the asset's system wallet in the same
+// transaction.
+func (a *adminService) SetWalletStatus(input *models.WalletStatusParams) (*models.WalletStatusResponse, error) {
+	target := repository.WalletStatus(input.Status)
+
+	var wallet repository.Wallet
+	var change repository.WalletStatusChange
+	var swept int64
+
+	err := a.repo.WithTransaction(a.ctx, func(q *repository.Queries) error {
+		if _, err := a.userWallet(q, input.WalletId); err != nil {
+			return err
+		}
+
+		current, err := q.LockWalletById(a.ctx, input.WalletId)
+		if err != nil {
+			return err
+		}
+
+		if current.Status == repository.WalletStatusCLOSED {
+			return models.ErrWalletClosed
+		}
+		if current.Status == target {
+			return models.ErrWalletStatusUnchanged
+		}
+
+		sweepTxn := pgtype.UUID{}
+		if target == repository.WalletStatusCLOSED {
+			txnId, amount, err := a.sweep(q, current)
+			if err != nil {
+				return err
+			}
+			if amount != 0 {
+				sweepTxn = pgtype.UUID{Bytes: txnId, Valid: true}
+			}
+			swept = amount
+		}
+
+		wallet, err = q.UpdateWalletStatus(a.ctx, repository.UpdateWalletStatusParams{
+			ID: current.ID,
+			Status: target,
+		})
+		if err != nil {
+			return err
+		}
+
+		change, err = q.CreateWalletStatusChange(a.ctx, repository.CreateWalletStatusChangeParams{
+			WalletID: current.ID,
+			FromStatus: current.Status,
+			ToStatus: target,
+			Reason: input.Reason,
+			ChangedBy: pgtype.UUID{Bytes: input.ActorId, Valid: true},
+			SweepTransactionID: sweepTxn,
+		})
+		return err
+	})
+	if err != nil {
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		a.log.Error().Err(err).Msg("failed to change wallet status")
+		return nil, models.NewAppError(err, "failed to change wallet status", 500)
+	}
+
+	a.log.Info().
+		Str("wallet_id", wallet.ID.String()).
+		Str("actor_id", input.ActorId.String()).
+		Str("from", string(change.FromStatus)).
+		Str("to", string(change.ToStatus)).
+		Int64("swept", swept).
+		Msg("wallet status changed")
+
+	data, err := a.respondWallet(a.repo.Queries(), wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WalletStatusResponse{
+		Wallet: *data,
+		Change: toWalletStatusChangeResponse(change),
+		SweptAmount: swept,
+	}, nil
+}
+
+func (a *adminService) WalletStatusHistory(walletId uuid.UUID) ([]models.WalletStatusChangeResponse, error) {
+	query := a.repo.Queries()
+
+	if _, err := a.userWallet(query, walletId); err != nil {
+		return nil, err
+	}
+
+	changes, err := query.ListWalletStatusChanges(a.ctx, walletId)
+	if err != nil {
+		a.log.Error().Err(err).Msg("failed to list wallet status changes")
+		return nil, models.NewAppError(err, "failed to get wallet status history", 500)
+	}
+
+	response := make([]models.WalletStatusChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		response = append(response, toWalletStatusChangeResponse(change))
+	}
+
+	return response, nil
+}
+
+// sweep empties a wallet that is being closed into the asset's system wallet
+// with a TRANSFER, and returns the transaction and the amount moved. The
+// caller holds the wallet's lock.
+func (a *adminService) sweep(q *repository.Queries, wallet repository.Wallet) (uuid.UUID, int64, error) {
+	// holds that ran out no longer count, live ones block the closure
+	if err := q.ExpireHolds(a.ctx, wallet.ID); err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	held, err := q.GetActiveHoldsTotal(a.ctx, wallet.ID)
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+	if held > 0 {
+		return uuid.Nil, 0, models.ErrWalletHasActiveHolds
+	}
+
+	balance, err := q.GetBalance(a.ctx, wallet.ID)
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+	if balance == 0 {
+		return uuid.Nil, 0, nil
+	}
+
+	systemWalletId, err := q.GetSystemWallet(a.ctx, wallet.AssetID)
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	tnx, err := q.CreateTxn(a.ctx, repository.CreateTxnParams{
+		ID: uuid.New(),
+		Type: repository.TransactionTypeTRANSFER,
+	})
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	// add ledger entry for the sweep on user account
+	_, err = q.PostLedger(a.ctx, repository.CreateLedgerParams{
+		Amount: -balance,
+		TransactionID: tnx.ID,
+		WalletID: wallet.ID,
+	})
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	// add ledger entry for the sweep on system account
+	_, err = q.PostLedger(a.ctx, repository.CreateLedgerParams{
+		Amount: balance,
+		TransactionID: tnx.ID,
+		WalletID: systemWalletId,
+	})
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	return tnx.ID, balance, nil
+}
+
+// userWallet loads a wallet owned by a user. SYSTEM, PROMOTION and CAMPAIGN
+// wallets are reported as not found; campaigns have their own routes.
+func (a *adminService) userWallet(q *repository.Queries, walletId uuid.UUID) (repository.Wallet, error) {
+	wallet, err := q.GetWalletById(a.ctx, walletId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.Wallet{}, models.ErrWalletNotFound
+		}
+		a.log.Error().Err(err).Msg("failed to get wallet")
+		return repository.Wallet{}, models.NewAppError(err, "failed to get wallet", 500)
+	}
+
+	if wallet.OwnerType != repository.WalletOwnerTypeUSER {
+		return repository.Wallet{}, models.ErrWalletNotFound
+	}
+
+	return wallet, nil
+}
+
+func (a *adminService) respondUser(q *repository.Queries, user repository.User) (*models.AdminUserResponse, error) {
+	wallets, err := q.ListWalletsByOwner(a.ctx, user.ID)
+	if err != nil {
+		a.log.Error().Err(err).Msg("failed to list wallets")
+		return nil, models.NewAppError(err, "failed to get user", 500)
+	}
+
+	response := &models.AdminUserResponse{
+		Id: user.ID,
+		Username: user.Username,
+		Role: string(user.Role),
+		LimitTier: string(user.LimitTier),
+		CreatedAt: user.CreatedAt.Time,
+		Wallets: make([]models.AdminWalletResponse, 0, len(wallets)),
+	}
+	for _, wallet := range wallets {
+		data, err := a.respondWallet(q, wallet)
+		if err != nil {
+			return nil, err
+		}
+		response.Wallets = append(response.Wallets, *data)
+	}
+
+	return response, nil
+}
+
+func (a *adminService) respondWallet(q *repository.Queries, wallet repository.Wallet) (*models.AdminWalletResponse, error) {
+	asset, err := q.GetAssetById(a.ctx, wallet.AssetID)
+	if err != nil {
+		a.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.ErrBalanceRetrievalFailed
+	}
+
+	balance, available, err := a.wallets().availableBalance(q, wallet.ID)
+	if err != nil {
+		a.log.Error().Err(err).Msg("failed to get balance for wallet")
+		return nil, models.ErrBalanceRetrievalFailed
+	}
+
+	response := &models.AdminWalletResponse{
+		Id: wallet.ID,
+		OwnerId: wallet.OwnerID,
+		Asset: asset.Code,
+		Decimals: asset.Decimals,
+		Balance: balance,
+		FormattedBalance: models.FormatAmount(balance, asset.Decimals),
+		AvailableBalance: available,
+		FormattedAvailableBalance: models.FormatAmount(available, asset.Decimals),
+		Status: string(wallet.Status),
+		CreatedAt: wallet.CreatedAt.Time,
+	}
+
+	return response, nil
+}
+
+func toWalletStatusChangeResponse(change repository.WalletStatusChange) models.WalletStatusChangeResponse {
+	response := models.WalletStatusChangeResponse{
+		Id: change.ID,
+		WalletId: change.WalletID,
+		FromStatus: string(change.FromStatus),
+		ToStatus: string(change.ToStatus),
+		Reason: change.Reason,
+		CreatedAt: change.CreatedAt,
+	}
+	if change.ChangedBy.Valid {
+		changedBy := uuid.UUID(change.ChangedBy.Bytes)
+		response.ChangedBy = &changedBy
+	}
+	if change.SweepTransactionID.Valid {
+		sweepId := uuid.UUID(change.SweepTransactionID.Bytes)
+		response.SweepTransactionId = &sweepId
+	}
+	return response
+}
+
+// wallets returns the wallet service whose balance and history helpers the
+// admin views share.
+func (a *adminService) wallets() *walletService {
+	return &walletService{
+		ctx: a.ctx,
+		log: a.log,
+		repo: a.repo,
+	}
+}
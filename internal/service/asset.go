@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+)
+
+// AssetService manages the assets wallets can hold. Creating an asset also
+// creates the SYSTEM, PROMOTION and TREASURY wallets every asset needs and
+// the default limits of each tier, so the asset can be used as soon as it
+// exists.
+type AssetService interface {
+	Create(*models.AssetRequest) (*models.AssetResponse, error)
+	List() ([]models.AssetResponse, error)
+	Get(code string) (*models.AssetResponse, error)
+	Update(code string, input *models.AssetUpdateRequest) (*models.AssetResponse, error)
+	Deactivate(code string) (*models.AssetResponse, error)
+}
+
+type assetService struct {
+	ctx context.Context
+	log zerolog.Logger
+	repo repository.Repository
+}
+
+type tierLimit struct {
+	tier repository.LimitTier
+	txnType repository.TransactionType
+	perTransaction int64
+	daily int64
+	monthly int64
+}
+
+// the same defaults the limits migration wrote for existing assets, which
+// all had 0 decimals. They are whole units, scaled to the new asset's minor
+// units by scaleLimit.
+var defaultTierLimits = []tierLimit{
+	{tier: repository.LimitTierSTANDARD, txnType: repository.TransactionTypeTOPUP, perTransaction: 100000, daily: 200000, monthly: 1000000},
+	{tier: repository.LimitTierSTANDARD, txnType: repository.TransactionTypeSPEND, perTransaction: 50000, daily: 100000, monthly: 500000},
+	{tier: repository.LimitTierSTANDARD, txnType: repository.TransactionTypeTRANSFER, perTransaction: 50000, daily: 100000, monthly: 500000},
+	{tier: repository.LimitTierVERIFIED, txnType: repository.TransactionTypeTOPUP, perTransaction: 1000000, daily: 2000000, monthly: 10000000},
+	{tier: repository.LimitTierVERIFIED, txnType: repository.TransactionTypeSPEND, perTransaction: 500000, daily: 1000000, monthly: 5000000},
+	{tier: repository.LimitTierVERIFIED, txnType: repository.TransactionTypeTRANSFER, perTransaction: 500000, daily: 1000000, monthly: 5000000},
+}
+
+var defaultMaxBalances = map[repository.LimitTier]int64{
+	repository.LimitTierSTANDARD: 1000000,
+	repository.LimitTierVERIFIED: 10000000,
+}
+
+func (s *assetService) Create(input *models.AssetRequest) (*models.AssetResponse, error) {
+	var asset repository.Asset
+
+	err := s.repo.WithTransaction(s.ctx, func(q *repository.Queries) error {
+		_, err := q.GetAssetByCode(s.ctx, input.Code)
+		if err == nil {
+			return models.ErrAssetExists
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		asset, err = q.CreateAsset(s.ctx, repository.CreateAssetParams{
+			Code: input.Code,
+			Name: input.Name,
+			Decimals: input.Decimals,
+		})
+		if err != nil {
+			// a concurrent create of the same code got in first
+			if isUniqueViolation(err) {
+				return models.ErrAssetExists
+			}
+			return err
+		}
+
+		return s.provision(q, asset)
+	})
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to create asset")
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "failed to create asset", 500)
+	}
+
+	s.log.Info().Str("asset", asset.Code).Int16("decimals", asset.Decimals).Msg("asset created")
+
+	return s.respond(s.repo.Queries(), asset)
+}
+
+func (s *assetService) List() ([]models.AssetResponse, error) {
+	query := s.repo.Queries()
+
+	assets, err := query.ListAssets(s.ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to list assets")
+		return nil, models.NewAppError(err, "failed to list assets", 500)
+	}
+
+	response := make([]models.AssetResponse, 0, len(assets))
+	for _, asset := range assets {
+		data, err := s.respond(query, asset)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, *data)
+	}
+
+	return response, nil
+}
+
+func (s *assetService) Get(code string) (*models.AssetResponse, error) {
+	query := s.repo.Queries()
+
+	asset, err := query.GetAssetByCode(s.ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrAssetNotFound
+		}
+		s.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "failed to get asset", 500)
+	}
+
+	return s.respond(query, asset)
+}
+
+// Update changes an asset's name, decimals or status. Decimals can only
+// change while no ledger entry uses the asset, since stored amounts would
+// otherwise change value. The asset's limits are rescaled with them, so a
+// cap keeps its value in whole units.
+func (s *assetService) Update(code string, input *models.AssetUpdateRequest) (*models.AssetResponse, error) {
+	var updated repository.Asset
+
+	err := s.repo.WithTransaction(s.ctx, func(q *repository.Queries) error {
+		asset, err := s.lockAsset(q, code)
+		if err != nil {
+			return err
+		}
+
+		params := repository.UpdateAssetParams{
+			ID: asset.ID,
+		}
+		if input.Name != nil {
+			params.Name = pgtype.Text{String: *input.Name, Valid: true}
+		}
+		if input.Status != nil {
+			params.Status = repository.NullAssetStatus{
+				AssetStatus: repository.AssetStatus(*input.Status),
+				Valid: true,
+			}
+		}
+		if input.Decimals != nil && *input.Decimals != asset.Decimals {
+			used, err := q.AssetHasLedgers(s.ctx, asset.ID)
+			if err != nil {
+				return err
+			}
+			if used {
+				return models.ErrAssetDecimalsInUse
+			}
+			params.Decimals = pgtype.Int2{Int16: *input.Decimals, Valid: true}
+
+			shift := int32(*input.Decimals - asset.Decimals)
+			err = q.RescaleTransactionLimits(s.ctx, repository.RescaleTransactionLimitsParams{
+				Shift: shift,
+				AssetID: asset.ID,
+			})
+			if err != nil {
+				return err
+			}
+			err = q.RescaleBalanceLimits(s.ctx, repository.RescaleBalanceLimitsParams{
+				Shift: shift,
+				AssetID: asset.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		updated, err = q.UpdateAsset(s.ctx, params)
+		return err
+	})
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to update asset")
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "failed to update asset", 500)
+	}
+
+	return s.respond(s.repo.Queries(), updated)
+}
+
+// Deactivate stops new top-ups in an asset. Balances stay where they are and
+// can still be read, spent and transferred.
+func (s *assetService) Deactivate(code string) (*models.AssetResponse, error) {
+	status := string(repository.AssetStatusINACTIVE)
+	return s.Update(code, &models.AssetUpdateRequest{Status: &status})
+}
+
+func (s *assetService) lockAsset(q *repository.Queries, code string) (repository.Asset, error) {
+	asset, err := q.GetAssetByCode(s.ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.Asset{}, models.ErrAssetNotFound
+		}
+		return repository.Asset{}, err
+	}
+
+	return q.LockAsset(s.ctx, asset.ID)
+}
+
+// provision creates what a new asset needs before it can be used: its SYSTEM,
+// PROMOTION and TREASURY wallets, owned by the asset itself, and the default
+// limits of every tier.
+func (s *assetService) provision(q *repository.Queries, asset repository.Asset) error {
+	ownerTypes := []repository.WalletOwnerType{
+		repository.WalletOwnerTypeSYSTEM,
+		repository.WalletOwnerTypePROMOTION,
+		repository.WalletOwnerTypeTREASURY,
+	}
+	for _, ownerType := range ownerTypes {
+		err := q.EnsureWallet(s.ctx, repository.EnsureWalletParams{
+			OwnerType: ownerType,
+			OwnerID: asset.ID,
+			AssetID: asset.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, limit := range defaultTierLimits {
+		err := q.EnsureTierTransactionLimit(s.ctx, repository.EnsureTierTransactionLimitParams{
+			Tier: limit.tier,
+			AssetID: asset.ID,
+			TransactionType: limit.txnType,
+			PerTransaction: pgtype.Int8{Int64: scaleLimit(limit.perTransaction, asset.Decimals), Valid: true},
+			Daily: pgtype.Int8{Int64: scaleLimit(limit.daily, asset.Decimals), Valid: true},
+			Monthly: pgtype.Int8{Int64: scaleLimit(limit.monthly, asset.Decimals), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for tier, maxBalance := range defaultMaxBalances {
+		err := q.EnsureTierBalanceLimit(s.ctx, repository.EnsureTierBalanceLimitParams{
+			Tier: tier,
+			AssetID: asset.ID,
+			MaxBalance: pgtype.Int8{Int64: scaleLimit(maxBalance, asset.Decimals), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scaleLimit converts a default limit in whole units to minor units of an
+// asset with the given decimals. A cap that would not fit a request amount
+// is lowered to models.MaxAmount.
+func scaleLimit(whole int64, decimals int16) int64 {
+	minor := whole
+	for range decimals {
+		if minor > models.MaxAmount/10 {
+			return models.MaxAmount
+		}
+		minor *= 10
+	}
+	return min(minor, models.MaxAmount)
+}
+
+// isUniqueViolation reports whether err is postgres refusing a row that
+// would break a unique constraint.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func (s *assetService) respond(q *repository.Queries, asset repository.Asset) (*models.AssetResponse, error) {
+	systemWalletId, err := q.GetSystemWallet(s.ctx, asset.ID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get system wallet")
+		return nil, models.NewAppError(err, "failed to get asset", 500)
+	}
+
+	promotionWalletId, err := q.GetPromotionWallet(s.ctx, asset.ID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get promotions wallet")
+		return nil, models.NewAppError(err, "failed to get asset", 500)
+	}
+
+	treasuryWalletId, err := q.GetTreasuryWallet(s.ctx, asset.ID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get treasury wallet")
+		return nil, models.NewAppError(err, "failed to get asset", 500)
+	}
+
+	return &models.AssetResponse{
+		Id: asset.ID,
+		Code: asset.Code,
+		Name: asset.Name,
+		Decimals: asset.Decimals,
+		Status: string(asset.Status),
+		SystemWalletId: systemWalletId,
+		PromotionWalletId: promotionWalletId,
+		TreasuryWalletId: treasuryWalletId,
+		CreatedAt: asset.CreatedAt.Time,
+		UpdatedAt: asset.UpdatedAt,
+	}, nil
+}
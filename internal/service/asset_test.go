@@ -0,0 +1,38 @@
+package service
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+)
+
+func TestScaleLimitNearMaxAmount(t *testing.T) {
+	property := func(w uint64, d uint64) bool {
+		decimals := int16(d % (models.MaxDecimals + 1))
+		unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+
+		// a third of the seeds land within two of the largest limit that
+		// scales to MaxAmount or less, and a third near the top of the range
+		var whole int64
+		switch w % 3 {
+		case 0:
+			whole = max(new(big.Int).Quo(big.NewInt(models.MaxAmount), unit).Int64()+int64(w/3%5)-2, 0)
+		case 1:
+			whole = math.MaxInt64 - int64(w/3%(1<<16))
+		default:
+			whole = int64(w / 3)
+		}
+
+		want := new(big.Int).Mul(big.NewInt(whole), unit)
+		if want.Cmp(big.NewInt(models.MaxAmount)) > 0 {
+			want.SetInt64(models.MaxAmount)
+		}
+		return scaleLimit(whole, decimals) == want.Int64()
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -2,30 +2,40 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/AdityaTote/wallet-service/internal/config"
 	"github.com/AdityaTote/wallet-service/internal/lib/utils"
 	"github.com/AdityaTote/wallet-service/internal/models"
 	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/AdityaTote/wallet-service/internal/validations"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog"
 )
 
 type AuthService interface {
 	Signup(models.UserParams) (*models.UserResponse ,error)
 	Signin(models.UserParams) (*models.UserResponse ,error)
+	Refresh(models.RefreshParams) (*models.TokenResponse, error)
+	Logout(sessionId uuid.UUID) error
+	LogoutAll(userId uuid.UUID) (*models.LogoutResponse, error)
+	ChangePassword(userId uuid.UUID, input models.PasswordChangeParams) (*models.TokenResponse, error)
 }
 
 type authService struct {
 	ctx context.Context
 	cfg *config.Config
 	log zerolog.Logger
-	repo *repository.Queries
+	repo repository.Repository
 }
 
 func (a *authService) Signup(input models.UserParams) (*models.UserResponse ,error) {
-	_, err := a.repo.GetUserByUsername(a.ctx, input.Username)
+	_, err := a.repo.Queries().GetUserByUsername(a.ctx, input.Username)
 	if err == nil {
 		a.log.Debug().Msg("user with username already exist")
 		return nil, fmt.Errorf("authentication failed")
@@ -37,109 +47,402 @@ func (a *authService) Signup(input models.UserParams) (*models.UserResponse ,err
 		return nil, fmt.Errorf("authentication failed")
 	}
 
-	user, err := a.repo.CreateUser(a.ctx, repository.CreateUserParams{
-		Username: input.Username,
-		Password: hash_pass,
-	})
+	var referredBy pgtype.UUID
+	if input.Referrer != "" {
+		referrer, err := a.repo.Queries().GetUserByUsername(a.ctx, input.Referrer)
+		if err != nil {
+			a.log.Debug().Msg("referrer does not exist")
+			return nil, models.ErrReferrerNotFound
+		}
+		referredBy = pgtype.UUID{Bytes: referrer.ID, Valid: true}
+	}
+
+	var user repository.User
+	var wallet repository.Wallet
+	var balance int64
+
+	// the user, their wallets and the signup bonuses are created together or not at all
+	err = a.repo.WithTransaction(a.ctx, func(q *repository.Queries) error {
+		user, err = q.CreateUser(a.ctx, repository.CreateUserParams{
+			Username: input.Username,
+			Password: hash_pass,
+			ReferredBy: referredBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		// every user gets a wallet in the default asset, bonus or not
+		codes := []string{config.DefaultAssetCode}
+		for code := range a.cfg.SignupBonuses {
+			if code != config.DefaultAssetCode {
+				codes = append(codes, code)
+			}
+		}
+		sort.Strings(codes[1:])
+
+		wallets := make([]repository.Wallet, 0, len(codes))
+		for _, code := range codes {
+			created, err := a.createSignupWallet(q, user.ID, code)
+			if err != nil {
+				return err
+			}
+			wallets = append(wallets, created)
+		}
+		wallet = wallets[0]
+
+		// signup campaigns pay out before the configured signup bonus
+		engine := &campaignEngine{ctx: a.ctx, log: a.log}
+		if err := engine.onSignup(q, user.ID); err != nil {
+			return err
+		}
+
+		for i, code := range codes {
+			if err := a.creditSignupBonus(q, wallets[i], a.cfg.SignupBonuses[code]); err != nil {
+				return err
+			}
+		}
 
+		// get balance for wallet
+		balance, err = q.GetBalance(a.ctx, wallet.ID)
+		return err
+	})
 	if err != nil {
-		a.log.Error().Err(err).Msg("failed to create user")
+		a.log.Error().Err(err).Msg("failed to sign up user")
 		return nil, fmt.Errorf("authentication failed")
 	}
-
-	assets, err := a.repo.GetAssetByCode(a.ctx, config.AssetCodeUC)
+	
+	// open a session and issue its tokens
+	tokens, err := a.openSession(user, input.UserAgent, input.IPAddress)
 	if err != nil {
-		a.log.Error().Err(err).Msg("failed to get asset")
+		a.log.Error().Err(err).Msg("failed to open session")
 		return nil, fmt.Errorf("authentication failed")
 	}
 
-	wallet, err := a.repo.CreateWallet(a.ctx, repository.CreateWalletParams{
+	return &models.UserResponse{
+		Id:       user.ID,
+		Username: user.Username,
+		WalletId: &wallet.ID,
+		Balance: &balance,
+		AccessToken: tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn: tokens.ExpiresIn,
+	}, nil
+}
+
+// createSignupWallet creates the user's wallet in an asset.
+func (a *authService) createSignupWallet(q *repository.Queries, userId uuid.UUID, code string) (repository.Wallet, error) {
+	asset, err := q.GetAssetByCode(a.ctx, code)
+	if err != nil {
+		return repository.Wallet{}, fmt.Errorf("failed to get asset %s: %w", code, err)
+	}
+
+	return q.CreateWallet(a.ctx, repository.CreateWalletParams{
 		OwnerType: repository.WalletOwnerTypeUSER,
-		OwnerID: user.ID,
-		AssetID: assets.ID,
+		OwnerID: userId,
+		AssetID: asset.ID,
 	})
+}
+
+// creditSignupBonus credits the configured signup bonus to a new wallet,
+// debiting the asset's promotions wallet.
+func (a *authService) creditSignupBonus(q *repository.Queries, wallet repository.Wallet, bonus int64) error {
+	if bonus == 0 {
+		return nil
+	}
+
+	promotionWalletId, err := q.GetPromotionWallet(a.ctx, wallet.AssetID)
 	if err != nil {
-		a.log.Error().Err(err).Msg("failed to create wallet")
-		return nil, fmt.Errorf("authentication failed")
+		return fmt.Errorf("failed to get promotions wallet: %w", err)
 	}
 
 	// initialize bonus transaction for user
-	tnx, err := a.repo.CreateTxn(a.ctx, repository.CreateTxnParams{
+	tnx, err := q.CreateTxn(a.ctx, repository.CreateTxnParams{
 		ID: uuid.New(),
 		Type: repository.TransactionTypeBONUS,
 	})
 	if err != nil {
-		a.log.Error().Err(err).Msg("failed to create transaction")
-		return nil, fmt.Errorf("authentication failed")
+		return err
 	}
 
-	// create ledger entry for bonus transaction
-	_, err = a.repo.CreateLedger(a.ctx, repository.CreateLedgerParams{
+	// add ledger entry for bonus on user account
+	_, err = q.PostLedger(a.ctx, repository.CreateLedgerParams{
+		Amount: bonus,
 		TransactionID: tnx.ID,
 		WalletID: wallet.ID,
-		Amount: config.InitialBonusAmount,
 	})
 	if err != nil {
-		a.log.Error().Err(err).Msg("failed to create ledger entry")
-		return nil, fmt.Errorf("authentication failed")
+		return err
+	}
+
+	// add ledger entry for bonus on promotions account
+	_, err = q.PostLedger(a.ctx, repository.CreateLedgerParams{
+		Amount: -bonus,
+		TransactionID: tnx.ID,
+		WalletID: promotionWalletId,
+	})
+	return err
+}
+
+func (a *authService) Signin(input models.UserParams) (*models.UserResponse ,error) {
+	ipAttempt, err := a.reserveIPAttempt(input.IPAddress)
+	if err != nil {
+		return nil, a.signinRejected(err, input.Username)
 	}
 
-	// get balance for wallet
-	balanceInterface, err := a.repo.GetBalance(a.ctx, wallet.ID)
+	// unknown usernames count against the IP only
+	user, err := a.repo.Queries().GetUserByUsername(a.ctx, input.Username)
 	if err != nil {
-		a.log.Error().Err(err).Msg("failed to get wallet balance")
+		a.log.Debug().Msg("user with username does not exist")
 		return nil, fmt.Errorf("authentication failed")
 	}
 
-	balance, ok := balanceInterface.(int64)
-	if !ok {
-		a.log.Error().Msg("failed to assert balance type")
+	if err := a.reserveUserAttempt(user.Username, ipAttempt); err != nil {
+		return nil, a.signinRejected(err, input.Username)
+	}
+
+	if !utils.VerifyPassword(user.Password, input.Password) {
+		a.log.Debug().Msg("password does not match")
 		return nil, fmt.Errorf("authentication failed")
 	}
-	
-	// generate access token
-	access_token, err := utils.GenerateAccessToken([]byte(a.cfg.JWTSecret), user.ID, wallet.ID)
+
+	if err := a.clearLoginFailures(user.Username); err != nil {
+		a.log.Error().Err(err).Msg("failed to clear signin failures")
+	}
+	if err := a.releaseLoginAttempt(ipAttempt); err != nil {
+		a.log.Error().Err(err).Msg("failed to release signin attempt")
+	}
+
+	tokens, err := a.openSession(user, input.UserAgent, input.IPAddress)
 	if err != nil {
-		a.log.Error().Err(err).Msg("failed to generate access token")
+		a.log.Error().Err(err).Msg("failed to open session")
 		return nil, fmt.Errorf("authentication failed")
 	}
 
 	return &models.UserResponse{
 		Id:       user.ID,
 		Username: user.Username,
-		WalletId: &wallet.ID,
-		Balance: &balance,
-		AccessToken: access_token,
+		AccessToken: tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn: tokens.ExpiresIn,
 	}, nil
 }
 
-func (a *authService) Signin(input models.UserParams) (*models.UserResponse ,error) {
-	user, err := a.repo.GetUserByUsername(a.ctx, input.Username)
+// signinRejected passes on the wait of a throttled signin and hides any
+// other error behind the generic signin failure.
+func (a *authService) signinRejected(err error, username string) error {
+	var retryErr *models.RetryAfterError
+	if errors.As(err, &retryErr) {
+		a.log.Debug().Str("username", username).Msg("signin throttled")
+		return retryErr
+	}
+	a.log.Error().Err(err).Msg("failed to reserve signin attempt")
+	return fmt.Errorf("authentication failed")
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token. The presented token stops working; presenting it again is taken as
+// a sign that it leaked and revokes the whole session.
+func (a *authService) Refresh(input models.RefreshParams) (*models.TokenResponse, error) {
+	hash := utils.HashRefreshToken(input.RefreshToken)
+
+	var session repository.Session
+	var user repository.User
+	var refreshToken string
+	reused := false
+
+	err := a.repo.WithTransaction(a.ctx, func(q *repository.Queries) error {
+		current, err := q.LockSessionByToken(a.ctx, hash)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		if current.RevokedAt.Valid || !current.ExpiresAt.After(time.Now()) {
+			return models.ErrInvalidRefreshToken
+		}
+
+		// the token was already rotated, so someone else may hold the new one
+		if current.RefreshTokenHash != hash {
+			reused = true
+			_, err := q.RevokeSession(a.ctx, current.ID)
+			return err
+		}
+
+		var refreshHash string
+		refreshToken, refreshHash, err = utils.GenerateRefreshToken()
+		if err != nil {
+			return err
+		}
+
+		session, err = q.RotateSession(a.ctx, repository.RotateSessionParams{
+			ID: current.ID,
+			RefreshTokenHash: refreshHash,
+			ExpiresAt: time.Now().Add(a.cfg.RefreshTokenTTL),
+		})
+		if err != nil {
+			return err
+		}
+
+		// the new access token carries the role the user has now
+		user, err = q.GetUserById(a.ctx, session.UserID)
+		return err
+	})
 	if err != nil {
-		a.log.Debug().Msg("user with username does not exist")
-		return nil, fmt.Errorf("authentication failed")
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			a.log.Debug().Err(err).Msg("refresh token rejected")
+			return nil, appErr
+		}
+		a.log.Error().Err(err).Msg("failed to refresh session")
+		return nil, models.NewAppError(err, "failed to refresh session", 500)
+	}
+	if reused {
+		a.log.Warn().Msg("refresh token reused, session revoked")
+		return nil, models.ErrRefreshTokenReused
 	}
 
-	if !utils.VerifyPassword(user.Password, input.Password) {
-		a.log.Debug().Msg("password does not match")
-		return nil, fmt.Errorf("authentication failed")
+	accessToken, err := utils.GenerateAccessToken(a.cfg.TokenKeys, user.ID, session.ID, string(user.Role), a.cfg.AccessTokenTTL)
+	if err != nil {
+		a.log.Error().Err(err).Msg("failed to generate access token")
+		return nil, models.NewAppError(err, "failed to refresh session", 500)
 	}
 
-	wallet, err := a.repo.GetWalletByOwner(a.ctx, user.ID)
+	return &models.TokenResponse{
+		AccessToken: accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn: int64(a.cfg.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// Logout revokes a single session. Access tokens issued for it are rejected
+// from the next request on.
+func (a *authService) Logout(sessionId uuid.UUID) error {
+	if _, err := a.repo.Queries().RevokeSession(a.ctx, sessionId); err != nil {
+		a.log.Error().Err(err).Msg("failed to revoke session")
+		return models.NewAppError(err, "failed to log out", 500)
+	}
+	return nil
+}
+
+// LogoutAll revokes every open session of a user.
+func (a *authService) LogoutAll(userId uuid.UUID) (*models.LogoutResponse, error) {
+	revoked, err := a.repo.Queries().RevokeUserSessions(a.ctx, userId)
 	if err != nil {
-		a.log.Error().Err(err).Msg("failed to get wallet for user")
-		return nil, fmt.Errorf("authentication failed")
+		a.log.Error().Err(err).Msg("failed to revoke sessions")
+		return nil, models.NewAppError(err, "failed to log out", 500)
 	}
 
-	access_token, err := utils.GenerateAccessToken([]byte(a.cfg.JWTSecret), user.ID, wallet.ID)
+	return &models.LogoutResponse{
+		Revoked: revoked,
+	}, nil
+}
+
+// ChangePassword replaces the user's password after checking the current
+// one, and revokes every session so tokens issued with the old password stop
+// working. The caller gets a new session. Wrong current passwords count as
+// failed signins.
+func (a *authService) ChangePassword(userId uuid.UUID, input models.PasswordChangeParams) (*models.TokenResponse, error) {
+	user, err := a.repo.Queries().GetUserById(a.ctx, userId)
 	if err != nil {
-		a.log.Error().Err(err).Msg("failed to generate access token")
-		return nil, fmt.Errorf("authentication failed")
+		a.log.Error().Err(err).Msg("failed to get user")
+		return nil, models.NewAppError(err, "failed to change password", 500)
 	}
 
-	return &models.UserResponse{
-		Id:       user.ID,
-		Username: user.Username,
-		AccessToken: access_token,
+	ipAttempt, err := a.reserveIPAttempt(input.IPAddress)
+	if err == nil {
+		err = a.reserveUserAttempt(user.Username, ipAttempt)
+	}
+	if err != nil {
+		var retryErr *models.RetryAfterError
+		if errors.As(err, &retryErr) {
+			return nil, retryErr
+		}
+		a.log.Error().Err(err).Msg("failed to reserve signin attempt")
+		return nil, models.NewAppError(err, "failed to change password", 500)
+	}
+
+	if !utils.VerifyPassword(user.Password, input.CurrentPassword) {
+		a.log.Debug().Msg("current password does not match")
+		return nil, models.ErrInvalidCurrentPassword
+	}
+
+	if err := a.clearLoginFailures(user.Username); err != nil {
+		a.log.Error().Err(err).Msg("failed to clear signin failures")
+	}
+	if err := a.releaseLoginAttempt(ipAttempt); err != nil {
+		a.log.Error().Err(err).Msg("failed to release signin attempt")
+	}
+
+	if input.NewPassword == input.CurrentPassword {
+		return nil, models.ErrPasswordUnchanged
+	}
+	if err := validations.ValidatePassword(input.NewPassword, user.Username, a.cfg); err != nil {
+		return nil, models.NewAppError(err, err.Error(), 422)
+	}
+
+	hash_pass, err := utils.HashPassword(input.NewPassword)
+	if err != nil {
+		a.log.Error().Err(err).Msg("failed to hash password")
+		return nil, models.NewAppError(err, "failed to change password", 500)
+	}
+
+	err = a.repo.WithTransaction(a.ctx, func(q *repository.Queries) error {
+		err := q.UpdateUserPassword(a.ctx, repository.UpdateUserPasswordParams{
+			ID: user.ID,
+			Password: hash_pass,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.RevokeUserSessions(a.ctx, user.ID)
+		return err
+	})
+	if err != nil {
+		a.log.Error().Err(err).Msg("failed to change password")
+		return nil, models.NewAppError(err, "failed to change password", 500)
+	}
+
+	tokens, err := a.openSession(user, input.UserAgent, input.IPAddress)
+	if err != nil {
+		a.log.Error().Err(err).Msg("failed to open session")
+		return nil, models.NewAppError(err, "password changed, sign in again", 500)
+	}
+
+	return tokens, nil
+}
+
+// openSession stores a new session for the user and issues its first pair
+// of tokens. Only the refresh token's hash is kept.
+func (a *authService) openSession(user repository.User, userAgent, ipAddress string) (*models.TokenResponse, error) {
+	refreshToken, refreshHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := a.repo.Queries().CreateSession(a.ctx, repository.CreateSessionParams{
+		ID: uuid.New(),
+		UserID: user.ID,
+		RefreshTokenHash: refreshHash,
+		UserAgent: userAgent,
+		IpAddress: ipAddress,
+		ExpiresAt: time.Now().Add(a.cfg.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := utils.GenerateAccessToken(a.cfg.TokenKeys, user.ID, session.ID, string(user.Role), a.cfg.AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenResponse{
+		AccessToken: accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn: int64(a.cfg.AccessTokenTTL.Seconds()),
 	}, nil
 }
\ No newline at end of file
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+// BalanceService maintains the cached balances in wallet_balances: it folds
+// in the pending changes of shared wallets, the ones not owned by a user,
+// writes checkpoints and proves the cache against the ledger.
+type BalanceService interface {
+	Verify() (*models.BalanceVerificationReport, error)
+	Fold() (int64, error)
+	Checkpoint(minEntries int64) (int, error)
+	RunCheckpointer(interval time.Duration, minEntries int64)
+}
+
+type balanceService struct {
+	ctx context.Context
+	log zerolog.Logger
+	repo repository.Repository
+}
+
+// Verify compares every wallet's cached balance with its latest checkpoint
+// plus the ledger tail and with the full ledger sum. All three are read in one
+// statement, so the check is consistent while the service keeps writing.
+func (s *balanceService) Verify() (*models.BalanceVerificationReport, error) {
+	rows, err := s.repo.Queries().VerifyWalletBalances(s.ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to verify wallet balances")
+		return nil, models.NewAppError(err, "failed to verify wallet balances", 500)
+	}
+
+	report := &models.BalanceVerificationReport{
+		CheckedAt: time.Now().UTC(),
+		WalletsChecked: len(rows),
+		Mismatches: []models.BalanceMismatch{},
+	}
+
+	for _, row := range rows {
+		if row.CachedBalance == row.LedgerBalance && row.CheckpointBalance == row.LedgerBalance {
+			continue
+		}
+
+		report.Mismatches = append(report.Mismatches, models.BalanceMismatch{
+			WalletId: row.WalletID,
+			OwnerType: string(row.OwnerType),
+			AssetId: row.AssetID,
+			CachedBalance: row.CachedBalance,
+			CheckpointBalance: row.CheckpointBalance,
+			LedgerBalance: row.LedgerBalance,
+		})
+	}
+	report.Consistent = len(report.Mismatches) == 0
+
+	return report, nil
+}
+
+// Fold adds the pending balance changes of shared wallets to their rows in
+// wallet_balances and returns how many wallets were updated. Changes from
+// transactions that have not committed yet are left for the next fold.
+// Balances read the same before and after, since they count both.
+func (s *balanceService) Fold() (int64, error) {
+	folded, err := s.repo.Queries().FoldWalletBalanceDeltas(s.ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to fold wallet balance deltas")
+		return 0, models.NewAppError(err, "fold failed", 500)
+	}
+
+	return folded, nil
+}
+
+// Checkpoint writes a checkpoint for every user wallet with at least minEntries
+// ledger entries since its last one and returns how many were written. A
+// wallet whose cached balance disagrees with its ledger is skipped and
+// reported through the returned error; the other wallets are still processed.
+//
+// Shared wallets are not checkpointed: their entries are numbered without a
+// lock, so an entry with a lower seq may still commit after a higher one and
+// no seq is known to be complete. Their balance is checked against the full
+// ledger sum instead.
+func (s *balanceService) Checkpoint(minEntries int64) (int, error) {
+	walletIds, err := s.repo.Queries().ListWalletsDueForCheckpoint(s.ctx, minEntries)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to list wallets due for checkpoint")
+		return 0, models.NewAppError(err, "checkpoint failed", 500)
+	}
+
+	written := 0
+	var failed error
+
+	for _, walletId := range walletIds {
+		ok, err := s.checkpointWallet(walletId)
+		if err != nil {
+			s.log.Error().Err(err).Str("wallet_id", walletId.String()).Msg("failed to checkpoint wallet")
+			failed = err
+			continue
+		}
+		if ok {
+			written++
+		}
+	}
+
+	if failed != nil {
+		var appErr *models.AppError
+		if errors.As(failed, &appErr) {
+			return written, appErr
+		}
+		return written, models.NewAppError(failed, "checkpoint failed", 500)
+	}
+
+	return written, nil
+}
+
+// checkpointWallet locks the wallet's balance row, which waits for every
+// in-flight ledger write of the wallet to commit, and then records the
+// previous checkpoint plus the ledger tail as the new checkpoint.
+func (s *balanceService) checkpointWallet(walletId uuid.UUID) (bool, error) {
+	written := false
+
+	err := s.repo.WithTransaction(s.ctx, func(q *repository.Queries) error {
+		cached, err := q.LockWalletBalance(s.ctx, walletId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		checkpoint, err := q.CreateBalanceCheckpoint(s.ctx, walletId)
+		if err != nil {
+			// no entries since the last checkpoint
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		if checkpoint.Balance != cached {
+			return models.ErrBalanceMismatch
+		}
+
+		written = true
+		return nil
+	})
+
+	return written, err
+}
+
+// RunCheckpointer folds shared wallets' balance changes and writes
+// checkpoints every interval until the service context is cancelled.
+func (s *balanceService) RunCheckpointer(interval time.Duration, minEntries int64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			folded, err := s.Fold()
+			if err != nil {
+				s.log.Error().Err(err).Msg("balance fold failed")
+			} else {
+				s.log.Info().Int64("folded", folded).Msg("balance fold finished")
+			}
+
+			written, err := s.Checkpoint(minEntries)
+			if err != nil {
+				s.log.Error().Err(err).Int("written", written).Msg("balance checkpoint run failed")
+				continue
+			}
+			s.log.Info().Int("written", written).Msg("balance checkpoint run finished")
+		}
+	}
+}
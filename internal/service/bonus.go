@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+// Bonus grants a campaign's reward to a user by hand. The amount defaults to
+// the campaign's fixed reward and is drawn from the campaign's budget like an
+// automatic reward.
+func (w *walletService) Bonus(input *models.BonusServiceParams) (*models.BonusResponse, error) {
+	query := w.repo.Queries()
+
+	_, err := query.GetUserById(w.ctx, input.UserId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrUserNotFound
+		}
+		w.log.Error().Err(err).Msg("failed to get user")
+		return nil, models.NewAppError(err, "bonus failed", 500)
+	}
+
+	campaign, err := query.GetCampaignById(w.ctx, input.CampaignId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrCampaignNotFound
+		}
+		w.log.Error().Err(err).Msg("failed to get campaign")
+		return nil, models.NewAppError(err, "bonus failed", 500)
+	}
+
+	asset, err := query.GetAssetById(w.ctx, campaign.AssetID)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "bonus failed", 500)
+	}
+
+	amount := campaign.Amount
+	if !input.Amount.IsZero() {
+		amount, err = input.Amount.MinorUnits(asset.Decimals)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key := newIdempotencyKey(input.TxnId, input.UserId, operationBonus, campaign.ID, amount)
+
+	var response models.BonusResponse
+
+	err = w.repo.WithTransaction(w.ctx, func(q *repository.Queries) error {
+		// a retried txn_id replays the response it got the first time
+		replayed, err := w.claimIdempotencyKey(q, key, &response)
+		if err != nil || replayed {
+			return err
+		}
+
+		// create the wallet on the first bonus in this asset
+		err = q.EnsureWallet(w.ctx, repository.EnsureWalletParams{
+			OwnerType: repository.WalletOwnerTypeUSER,
+			OwnerID: input.UserId,
+			AssetID: campaign.AssetID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// lock the user's wallet before grant locks the campaign row, so a
+		// manual grant and a top-up reward for the same user cannot deadlock
+		wallet, err := q.LockWallet(w.ctx, repository.LockWalletParams{
+			OwnerID: input.UserId,
+			AssetID: campaign.AssetID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := checkWalletCredit(wallet); err != nil {
+			return err
+		}
+
+		tnx, err := w.campaigns().grant(q, campaign.ID, input.UserId, amount, input.TxnId)
+		if err != nil {
+			return err
+		}
+
+		balance, err := q.GetBalance(w.ctx, wallet.ID)
+		if err != nil {
+			return err
+		}
+
+		response = models.BonusResponse{
+			TransactionId: tnx.ID,
+			CampaignId: campaign.ID,
+			UserId: input.UserId,
+			Amount: amount,
+			FormattedAmount: models.FormatAmount(amount, asset.Decimals),
+			Balance: balance,
+			FormattedBalance: models.FormatAmount(balance, asset.Decimals),
+		}
+
+		return w.saveIdempotentResponse(q, input.TxnId, response)
+	})
+
+	if err != nil {
+		w.log.Error().Err(err).Msg("bonus failed")
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "bonus failed", 500)
+	}
+
+	return &response, nil
+}
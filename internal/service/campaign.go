@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+)
+
+// CampaignService manages promotion campaigns. Every campaign owns a CAMPAIGN
+// wallet that is funded from the asset's promotions wallet when the campaign
+// is created, so its budget is real money on the ledger.
+type CampaignService interface {
+	Create(*models.CampaignRequest) (*models.CampaignResponse, error)
+	List() ([]models.CampaignResponse, error)
+	Get(id uuid.UUID) (*models.CampaignResponse, error)
+	Update(id uuid.UUID, input *models.CampaignUpdateRequest) (*models.CampaignResponse, error)
+	End(id uuid.UUID) (*models.CampaignResponse, error)
+}
+
+type campaignService struct {
+	ctx context.Context
+	log zerolog.Logger
+	repo repository.Repository
+}
+
+func (s *campaignService) Create(input *models.CampaignRequest) (*models.CampaignResponse, error) {
+	code := input.Asset
+	if code == "" {
+		code = config.DefaultAssetCode
+	}
+
+	asset, err := s.repo.Queries().GetAssetByCode(s.ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrAssetNotFound
+		}
+		s.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "failed to create campaign", 500)
+	}
+	if asset.Status != repository.AssetStatusACTIVE {
+		return nil, models.ErrAssetInactive
+	}
+
+	var amount int64
+	if !input.Amount.IsZero() {
+		amount, err = input.Amount.MinorUnits(asset.Decimals)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	budget, err := input.Budget.MinorUnits(asset.Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.CreateCampaignParams{
+		ID: uuid.New(),
+		Name: input.Name,
+		Rule: repository.CampaignRule(input.Rule),
+		AssetID: asset.ID,
+		Amount: amount,
+		MatchPercent: input.MatchPercent,
+		Budget: budget,
+		StartsAt: time.Now(),
+	}
+	if !input.MaxReward.IsZero() {
+		maxReward, err := input.MaxReward.MinorUnits(asset.Decimals)
+		if err != nil {
+			return nil, err
+		}
+		params.MaxReward = pgtype.Int8{Int64: maxReward, Valid: true}
+	}
+	if input.StartsAt != nil {
+		params.StartsAt = *input.StartsAt
+	}
+	if input.EndsAt != nil {
+		params.EndsAt = pgtype.Timestamptz{Time: *input.EndsAt, Valid: true}
+	}
+
+	var campaign repository.Campaign
+
+	err = s.repo.WithTransaction(s.ctx, func(q *repository.Queries) error {
+		wallet, err := q.CreateWallet(s.ctx, repository.CreateWalletParams{
+			OwnerType: repository.WalletOwnerTypeCAMPAIGN,
+			OwnerID: params.ID,
+			AssetID: asset.ID,
+		})
+		if err != nil {
+			return err
+		}
+		params.WalletID = wallet.ID
+
+		campaign, err = q.CreateCampaign(s.ctx, params)
+		if err != nil {
+			return err
+		}
+
+		return s.fund(q, campaign, campaign.Budget)
+	})
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to create campaign")
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "failed to create campaign", 500)
+	}
+
+	response := toCampaignResponse(campaign, asset)
+	return &response, nil
+}
+
+func (s *campaignService) List() ([]models.CampaignResponse, error) {
+	query := s.repo.Queries()
+
+	campaigns, err := query.ListCampaigns(s.ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to list campaigns")
+		return nil, models.NewAppError(err, "failed to list campaigns", 500)
+	}
+
+	assets := make(map[uuid.UUID]repository.Asset)
+	response := make([]models.CampaignResponse, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		asset, ok := assets[campaign.AssetID]
+		if !ok {
+			asset, err = query.GetAssetById(s.ctx, campaign.AssetID)
+			if err != nil {
+				s.log.Error().Err(err).Msg("failed to get asset")
+				return nil, models.NewAppError(err, "failed to list campaigns", 500)
+			}
+			assets[campaign.AssetID] = asset
+		}
+
+		response = append(response, toCampaignResponse(campaign, asset))
+	}
+
+	return response, nil
+}
+
+func (s *campaignService) Get(id uuid.UUID) (*models.CampaignResponse, error) {
+	query := s.repo.Queries()
+
+	campaign, err := query.GetCampaignById(s.ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrCampaignNotFound
+		}
+		s.log.Error().Err(err).Msg("failed to get campaign")
+		return nil, models.NewAppError(err, "failed to get campaign", 500)
+	}
+
+	return s.respond(query, campaign)
+}
+
+// Update changes a campaign's name, status, budget or end time. A budget
+// change moves the difference between the promotions wallet and the
+// campaign's wallet in the same transaction.
+func (s *campaignService) Update(id uuid.UUID, input *models.CampaignUpdateRequest) (*models.CampaignResponse, error) {
+	var updated repository.Campaign
+
+	err := s.repo.WithTransaction(s.ctx, func(q *repository.Queries) error {
+		campaign, err := s.lockCampaign(q, id)
+		if err != nil {
+			return err
+		}
+
+		params := repository.UpdateCampaignParams{
+			ID: campaign.ID,
+		}
+		if input.Name != nil {
+			params.Name = pgtype.Text{String: *input.Name, Valid: true}
+		}
+		if input.Status != nil {
+			params.Status = repository.NullCampaignStatus{
+				CampaignStatus: repository.CampaignStatus(*input.Status),
+				Valid: true,
+			}
+		}
+		if input.EndsAt != nil {
+			params.EndsAt = pgtype.Timestamptz{Time: *input.EndsAt, Valid: true}
+		}
+		if input.Budget != nil {
+			asset, err := q.GetAssetById(s.ctx, campaign.AssetID)
+			if err != nil {
+				return err
+			}
+
+			budget, err := input.Budget.MinorUnits(asset.Decimals)
+			if err != nil {
+				return err
+			}
+			if budget < campaign.Spent {
+				return models.ErrCampaignBudgetBelowSpent
+			}
+			if err := s.fund(q, campaign, budget-campaign.Budget); err != nil {
+				return err
+			}
+			params.Budget = pgtype.Int8{Int64: budget, Valid: true}
+		}
+
+		updated, err = q.UpdateCampaign(s.ctx, params)
+		return err
+	})
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to update campaign")
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "failed to update campaign", 500)
+	}
+
+	return s.respond(s.repo.Queries(), updated)
+}
+
+// End stops a campaign for good and returns its unspent budget to the
+// promotions wallet.
+func (s *campaignService) End(id uuid.UUID) (*models.CampaignResponse, error) {
+	var ended repository.Campaign
+
+	err := s.repo.WithTransaction(s.ctx, func(q *repository.Queries) error {
+		campaign, err := s.lockCampaign(q, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.fund(q, campaign, campaign.Spent-campaign.Budget); err != nil {
+			return err
+		}
+
+		params := repository.UpdateCampaignParams{
+			ID: campaign.ID,
+			Status: repository.NullCampaignStatus{
+				CampaignStatus: repository.CampaignStatusENDED,
+				Valid: true,
+			},
+		}
+		now := time.Now()
+		if !campaign.EndsAt.Valid || campaign.EndsAt.Time.After(now) {
+			params.EndsAt = pgtype.Timestamptz{Time: now, Valid: true}
+		}
+
+		ended, err = q.UpdateCampaign(s.ctx, params)
+		return err
+	})
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to end campaign")
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "failed to end campaign", 500)
+	}
+
+	return s.respond(s.repo.Queries(), ended)
+}
+
+// lockCampaign locks a campaign that can still be changed.
+func (s *campaignService) lockCampaign(q *repository.Queries, id uuid.UUID) (repository.Campaign, error) {
+	campaign, err := q.LockCampaign(s.ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.Campaign{}, models.ErrCampaignNotFound
+		}
+		return repository.Campaign{}, err
+	}
+
+	if campaign.Status == repository.CampaignStatusENDED {
+		return repository.Campaign{}, models.ErrCampaignEnded
+	}
+
+	return campaign, nil
+}
+
+// fund moves amount from the promotions wallet into the campaign's wallet, or
+// back when amount is negative.
+func (s *campaignService) fund(q *repository.Queries, campaign repository.Campaign, amount int64) error {
+	if amount == 0 {
+		return nil
+	}
+
+	promotionWalletId, err := q.GetPromotionWallet(s.ctx, campaign.AssetID)
+	if err != nil {
+		return err
+	}
+
+	tnx, err := q.CreateTxn(s.ctx, repository.CreateTxnParams{
+		ID: uuid.New(),
+		Type: repository.TransactionTypeTRANSFER,
+	})
+	if err != nil {
+		return err
+	}
+
+	// add ledger entry for funding on campaign account
+	_, err = q.PostLedger(s.ctx, repository.CreateLedgerParams{
+		Amount: amount,
+		TransactionID: tnx.ID,
+		WalletID: campaign.WalletID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// add ledger entry for funding on promotions account
+	_, err = q.PostLedger(s.ctx, repository.CreateLedgerParams{
+		Amount: -amount,
+		TransactionID: tnx.ID,
+		WalletID: promotionWalletId,
+	})
+	return err
+}
+
+func (s *campaignService) respond(q *repository.Queries, campaign repository.Campaign) (*models.CampaignResponse, error) {
+	asset, err := q.GetAssetById(s.ctx, campaign.AssetID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "failed to get campaign", 500)
+	}
+
+	response := toCampaignResponse(campaign, asset)
+	return &response, nil
+}
+
+func toCampaignResponse(campaign repository.Campaign, asset repository.Asset) models.CampaignResponse {
+	response := models.CampaignResponse{
+		Id: campaign.ID,
+		Name: campaign.Name,
+		Rule: string(campaign.Rule),
+		Asset: asset.Code,
+		Status: string(campaign.Status),
+		Amount: campaign.Amount,
+		FormattedAmount: models.FormatAmount(campaign.Amount, asset.Decimals),
+		MatchPercent: campaign.MatchPercent,
+		Budget: campaign.Budget,
+		FormattedBudget: models.FormatAmount(campaign.Budget, asset.Decimals),
+		Spent: campaign.Spent,
+		FormattedSpent: models.FormatAmount(campaign.Spent, asset.Decimals),
+		WalletId: campaign.WalletID,
+		StartsAt: campaign.StartsAt,
+		CreatedAt: campaign.CreatedAt,
+		UpdatedAt: campaign.UpdatedAt,
+	}
+	// an ended campaign has handed its unspent budget back
+	if campaign.Status != repository.CampaignStatusENDED {
+		response.Remaining = campaign.Budget - campaign.Spent
+	}
+	response.FormattedRemaining = models.FormatAmount(response.Remaining, asset.Decimals)
+	if campaign.MaxReward.Valid {
+		maxReward := campaign.MaxReward.Int64
+		formattedMaxReward := models.FormatAmount(maxReward, asset.Decimals)
+		response.MaxReward = &maxReward
+		response.FormattedMaxReward = &formattedMaxReward
+	}
+	if campaign.EndsAt.Valid {
+		endsAt := campaign.EndsAt.Time
+		response.EndsAt = &endsAt
+	}
+	return response
+}
@@ -0,0 +1,397 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Quote prices a conversion at the rate in effect now and locks that price
+// for the caller until the quote expires.
+func (w *walletService) Quote(input *models.QuoteServiceParams) (*models.QuoteResponse, error) {
+	query := w.repo.Queries()
+
+	from, err := w.resolveAsset(query, input.FromAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := w.resolveAsset(query, input.ToAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.ID == to.ID {
+		return nil, models.ErrSameAssetConversion
+	}
+
+	// converting pays into the target asset, which an inactive asset refuses
+	if to.Status != repository.AssetStatusACTIVE {
+		return nil, models.ErrAssetInactive
+	}
+
+	amount, err := input.Amount.MinorUnits(from.Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := query.GetCurrentFxRate(w.ctx, repository.GetCurrentFxRateParams{
+		FromAssetID: from.ID,
+		ToAssetID: to.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNoFxRate
+		}
+		w.log.Error().Err(err).Msg("failed to get exchange rate")
+		return nil, models.NewAppError(err, "failed to create quote", 500)
+	}
+
+	converted, err := convertAmount(amount, rate.Rate, from.Decimals, to.Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := config.DefaultQuoteTTL
+	if input.ExpiresIn > 0 {
+		ttl = time.Duration(input.ExpiresIn) * time.Second
+	}
+
+	quote, err := query.CreateFxQuote(w.ctx, repository.CreateFxQuoteParams{
+		UserID: input.UserId,
+		RateID: rate.ID,
+		FromAssetID: from.ID,
+		ToAssetID: to.ID,
+		Rate: rate.Rate,
+		FromAmount: amount,
+		ToAmount: converted,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to create quote")
+		return nil, models.NewAppError(err, "failed to create quote", 500)
+	}
+
+	return toQuoteResponse(quote, from, to), nil
+}
+
+func (w *walletService) GetQuote(userId uuid.UUID, quoteId uuid.UUID) (*models.QuoteResponse, error) {
+	query := w.repo.Queries()
+
+	quote, err := query.GetFxQuoteById(w.ctx, quoteId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrQuoteNotFound
+		}
+		w.log.Error().Err(err).Msg("failed to get quote")
+		return nil, models.NewAppError(err, "failed to get quote", 500)
+	}
+
+	// another user's quote is reported as missing
+	if quote.UserID != userId {
+		return nil, models.ErrQuoteNotFound
+	}
+
+	from, err := query.GetAssetById(w.ctx, quote.FromAssetID)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "failed to get quote", 500)
+	}
+
+	to, err := query.GetAssetById(w.ctx, quote.ToAssetID)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "failed to get quote", 500)
+	}
+
+	return toQuoteResponse(quote, from, to), nil
+}
+
+// Convert carries out a quote: it debits the caller's wallet in the quote's
+// source asset and credits their wallet in the target asset. The two assets
+// cannot share ledger entries, so each side is balanced against the asset's
+// TREASURY wallet and the ledger of each asset still sums to zero:
+//
+//	user wallet, from asset      -from_amount
+//	treasury wallet, from asset  +from_amount
+//	treasury wallet, to asset    -to_amount
+//	user wallet, to asset        +to_amount
+func (w *walletService) Convert(input *models.ConvertServiceParams) (*models.ConvertResponse, error) {
+	key := newIdempotencyKey(input.TxnId, input.UserId, operationConvert, input.QuoteId)
+
+	var response models.ConvertResponse
+
+	err := w.repo.WithTransaction(w.ctx, func(q *repository.Queries) error {
+		// a retried txn_id replays the response it got the first time
+		replayed, err := w.claimIdempotencyKey(q, key, &response)
+		if err != nil || replayed {
+			return err
+		}
+
+		quote, err := q.GetFxQuoteById(w.ctx, input.QuoteId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.ErrQuoteNotFound
+			}
+			return err
+		}
+
+		if quote.UserID != input.UserId {
+			return models.ErrQuoteNotFound
+		}
+
+		from, err := q.GetAssetById(w.ctx, quote.FromAssetID)
+		if err != nil {
+			return err
+		}
+
+		to, err := q.GetAssetById(w.ctx, quote.ToAssetID)
+		if err != nil {
+			return err
+		}
+
+		// an inactive asset still pays out, but takes no new money
+		if to.Status != repository.AssetStatusACTIVE {
+			return models.ErrAssetInactive
+		}
+
+		fromWallet, err := q.GetWalletByOwner(w.ctx, repository.GetWalletByOwnerParams{
+			OwnerID: input.UserId,
+			AssetID: from.ID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.ErrWalletNotFound
+			}
+			return err
+		}
+
+		// create the wallet on the first conversion into this asset
+		err = q.EnsureWallet(w.ctx, repository.EnsureWalletParams{
+			OwnerType: repository.WalletOwnerTypeUSER,
+			OwnerID: input.UserId,
+			AssetID: to.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		toWallet, err := q.GetWalletByOwner(w.ctx, repository.GetWalletByOwnerParams{
+			OwnerID: input.UserId,
+			AssetID: to.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// lock both wallet rows in id order, as transfers do
+		first, second := fromWallet.ID, toWallet.ID
+		if bytes.Compare(second[:], first[:]) < 0 {
+			first, second = second, first
+		}
+		for _, walletId := range []uuid.UUID{first, second} {
+			locked, err := q.LockWalletById(w.ctx, walletId)
+			if err != nil {
+				return err
+			}
+
+			if locked.ID == fromWallet.ID {
+				fromWallet = locked
+				if err := checkWalletDebit(locked); err != nil {
+					return err
+				}
+			} else {
+				toWallet = locked
+				if err := checkWalletCredit(locked); err != nil {
+					return err
+				}
+			}
+		}
+
+		// the quote is locked after the wallets, so a second conversion of
+		// it waits here and then finds it used
+		quote, err = q.LockFxQuote(w.ctx, quote.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkQuoteActive(quote); err != nil {
+			return err
+		}
+
+		// check balance for source wallet, leaving funds reserved by holds untouched
+		_, available, err := w.availableBalance(q, fromWallet.ID)
+		if err != nil {
+			return err
+		}
+
+		if available < quote.FromAmount {
+			return models.ErrInsufficientBalance
+		}
+
+		err = w.limits().check(q, input.UserId, fromWallet, from, repository.TransactionTypeCONVERT, quote.FromAmount)
+		if err != nil {
+			return err
+		}
+
+		err = w.limits().checkBalance(q, input.UserId, toWallet, to, repository.TransactionTypeCONVERT, quote.ToAmount)
+		if err != nil {
+			return err
+		}
+
+		// create tnx
+		tnx, err := q.CreateTxn(w.ctx, repository.CreateTxnParams{
+			ID: input.TxnId,
+			Type: repository.TransactionTypeCONVERT,
+		})
+		if err != nil {
+			return err
+		}
+
+		// add ledger entries for the user's side of the conversion
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: -quote.FromAmount,
+			TransactionID: tnx.ID,
+			WalletID: fromWallet.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: quote.ToAmount,
+			TransactionID: tnx.ID,
+			WalletID: toWallet.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		fromTreasuryId, err := q.GetTreasuryWallet(w.ctx, from.ID)
+		if err != nil {
+			return err
+		}
+
+		toTreasuryId, err := q.GetTreasuryWallet(w.ctx, to.ID)
+		if err != nil {
+			return err
+		}
+
+		// add ledger entries for the treasury side of the conversion
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: quote.FromAmount,
+			TransactionID: tnx.ID,
+			WalletID: fromTreasuryId,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: -quote.ToAmount,
+			TransactionID: tnx.ID,
+			WalletID: toTreasuryId,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.UseFxQuote(w.ctx, repository.UseFxQuoteParams{
+			ID: quote.ID,
+			TransactionID: pgtype.UUID{Bytes: tnx.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		fromBalance, err := q.GetBalance(w.ctx, fromWallet.ID)
+		if err != nil {
+			return err
+		}
+
+		toBalance, err := q.GetBalance(w.ctx, toWallet.ID)
+		if err != nil {
+			return err
+		}
+
+		response = models.ConvertResponse{
+			TransactionId: tnx.ID,
+			QuoteId: quote.ID,
+			FromAsset: from.Code,
+			ToAsset: to.Code,
+			Rate: formatRate(quote.Rate),
+			FromAmount: quote.FromAmount,
+			FormattedFromAmount: models.FormatAmount(quote.FromAmount, from.Decimals),
+			ToAmount: quote.ToAmount,
+			FormattedToAmount: models.FormatAmount(quote.ToAmount, to.Decimals),
+			FromBalance: fromBalance,
+			FormattedFromBalance: models.FormatAmount(fromBalance, from.Decimals),
+			ToBalance: toBalance,
+			FormattedToBalance: models.FormatAmount(toBalance, to.Decimals),
+		}
+
+		return w.saveIdempotentResponse(q, input.TxnId, response)
+	})
+
+	if err != nil {
+		w.log.Error().Err(err).Msg("conversion failed")
+
+		// keep the breach details for the response
+		var limitErr *models.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr
+		}
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "conversion failed", 500)
+	}
+
+	return &response, nil
+}
+
+func checkQuoteActive(quote repository.FxQuote) error {
+	if quote.TransactionID.Valid {
+		return models.ErrQuoteUsed
+	}
+	if !quote.ExpiresAt.After(time.Now()) {
+		return models.ErrQuoteExpired
+	}
+	return nil
+}
+
+func toQuoteResponse(quote repository.FxQuote, from repository.Asset, to repository.Asset) *models.QuoteResponse {
+	response := &models.QuoteResponse{
+		Id: quote.ID,
+		FromAsset: from.Code,
+		ToAsset: to.Code,
+		Rate: formatRate(quote.Rate),
+		FromAmount: quote.FromAmount,
+		FormattedFromAmount: models.FormatAmount(quote.FromAmount, from.Decimals),
+		ToAmount: quote.ToAmount,
+		FormattedToAmount: models.FormatAmount(quote.ToAmount, to.Decimals),
+		Status: models.QuoteStatusActive,
+		ExpiresAt: quote.ExpiresAt,
+		CreatedAt: quote.CreatedAt,
+	}
+
+	switch {
+	case quote.TransactionID.Valid:
+		transactionId := uuid.UUID(quote.TransactionID.Bytes)
+		response.TransactionId = &transactionId
+		response.Status = models.QuoteStatusUsed
+	case !quote.ExpiresAt.After(time.Now()):
+		response.Status = models.QuoteStatusExpired
+	}
+
+	return response
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeDB answers single-row queries from fixed rows keyed by the query's
+// sqlc name. A query without a row returns pgx.ErrNoRows, and a nil column
+// leaves the scanned field at its zero value.
+type fakeDB struct {
+	rows map[string][]any
+}
+
+func newFakeQueries(rows map[string][]any) *repository.Queries {
+	return repository.New(&fakeDB{rows: rows})
+}
+
+func (db *fakeDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (db *fakeDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("fakeDB: Query is not supported")
+}
+
+func (db *fakeDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	name := strings.Fields(strings.TrimPrefix(sql, "-- name: "))[0]
+	values, ok := db.rows[name]
+	if !ok {
+		return fakeRow{err: pgx.ErrNoRows}
+	}
+	return fakeRow{values: values}
+}
+
+type fakeRow struct {
+	values []any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, d := range dest {
+		if i < len(r.values) && r.values[i] != nil {
+			reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r.values[i]))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+)
+
+// FxService manages the exchange rates conversions are quoted at. Rates are
+// directional and never edited: a new rate for a pair takes over from its
+// valid_from, and ending a rate closes its validity window.
+type FxService interface {
+	CreateRate(*models.FxRateServiceParams) (*models.FxRateResponse, error)
+	ListRates() ([]models.FxRateResponse, error)
+	GetRate(id uuid.UUID) (*models.FxRateResponse, error)
+	EndRate(id uuid.UUID) (*models.FxRateResponse, error)
+}
+
+type fxService struct {
+	ctx context.Context
+	log zerolog.Logger
+	repo repository.Repository
+}
+
+func (s *fxService) CreateRate(input *models.FxRateServiceParams) (*models.FxRateResponse, error) {
+	query := s.repo.Queries()
+
+	from, err := s.getAsset(query, input.FromAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.getAsset(query, input.ToAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.ID == to.ID {
+		return nil, models.ErrSameAssetConversion
+	}
+
+	rate, err := parseRate(input.Rate)
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.CreateFxRateParams{
+		FromAssetID: from.ID,
+		ToAssetID: to.ID,
+		Rate: rate,
+		ValidFrom: time.Now(),
+		CreatedBy: pgtype.UUID{Bytes: input.ActorId, Valid: true},
+	}
+	if input.ValidFrom != nil {
+		params.ValidFrom = *input.ValidFrom
+	}
+	if input.ValidUntil != nil {
+		if !input.ValidUntil.After(params.ValidFrom) {
+			return nil, models.ErrInvalidRateWindow
+		}
+		params.ValidUntil = pgtype.Timestamptz{Time: *input.ValidUntil, Valid: true}
+	}
+
+	created, err := query.CreateFxRate(s.ctx, params)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to create exchange rate")
+		return nil, models.NewAppError(err, "failed to create exchange rate", 500)
+	}
+
+	s.log.Info().
+		Str("fx_rate_id", created.ID.String()).
+		Str("from_asset", from.Code).
+		Str("to_asset", to.Code).
+		Str("rate", formatRate(created.Rate)).
+		Str("actor_id", input.ActorId.String()).
+		Msg("exchange rate created")
+
+	return s.respond(created, s.assetCodes(query))
+}
+
+func (s *fxService) ListRates() ([]models.FxRateResponse, error) {
+	query := s.repo.Queries()
+
+	rates, err := query.ListFxRates(s.ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to list exchange rates")
+		return nil, models.NewAppError(err, "failed to list exchange rates", 500)
+	}
+
+	assetCode := s.assetCodes(query)
+	response := make([]models.FxRateResponse, 0, len(rates))
+	for _, rate := range rates {
+		data, err := s.respond(rate, assetCode)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, *data)
+	}
+
+	return response, nil
+}
+
+func (s *fxService) GetRate(id uuid.UUID) (*models.FxRateResponse, error) {
+	query := s.repo.Queries()
+
+	rate, err := query.GetFxRateById(s.ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrFxRateNotFound
+		}
+		s.log.Error().Err(err).Msg("failed to get exchange rate")
+		return nil, models.NewAppError(err, "failed to get exchange rate", 500)
+	}
+
+	return s.respond(rate, s.assetCodes(query))
+}
+
+// EndRate closes a rate's validity window now. A rate that has not started
+// yet ends at its valid_from, so it never takes effect. Quotes already given
+// at the rate keep their price until they expire.
+func (s *fxService) EndRate(id uuid.UUID) (*models.FxRateResponse, error) {
+	query := s.repo.Queries()
+
+	rate, err := query.GetFxRateById(s.ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrFxRateNotFound
+		}
+		s.log.Error().Err(err).Msg("failed to get exchange rate")
+		return nil, models.NewAppError(err, "failed to end exchange rate", 500)
+	}
+
+	if rate.ValidUntil.Valid && !rate.ValidUntil.Time.After(time.Now()) {
+		return nil, models.ErrFxRateEnded
+	}
+
+	ended, err := query.EndFxRate(s.ctx, rate.ID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to end exchange rate")
+		return nil, models.NewAppError(err, "failed to end exchange rate", 500)
+	}
+
+	s.log.Info().Str("fx_rate_id", ended.ID.String()).Msg("exchange rate ended")
+
+	return s.respond(ended, s.assetCodes(query))
+}
+
+func (s *fxService) getAsset(q *repository.Queries, code string) (repository.Asset, error) {
+	asset, err := q.GetAssetByCode(s.ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.Asset{}, models.ErrAssetNotFound
+		}
+		s.log.Error().Err(err).Msg("failed to get asset")
+		return repository.Asset{}, models.NewAppError(err, "failed to retrieve asset", 500)
+	}
+
+	return asset, nil
+}
+
+// assetCodes returns a lookup of asset codes by id that reads each asset
+// once, for responses listing many rates.
+func (s *fxService) assetCodes(q *repository.Queries) func(uuid.UUID) (string, error) {
+	codes := map[uuid.UUID]string{}
+	return func(assetId uuid.UUID) (string, error) {
+		if code, ok := codes[assetId]; ok {
+			return code, nil
+		}
+		asset, err := q.GetAssetById(s.ctx, assetId)
+		if err != nil {
+			return "", err
+		}
+		codes[assetId] = asset.Code
+		return asset.Code, nil
+	}
+}
+
+func (s *fxService) respond(rate repository.FxRate, assetCode func(uuid.UUID) (string, error)) (*models.FxRateResponse, error) {
+	from, err := assetCode(rate.FromAssetID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "failed to get exchange rate", 500)
+	}
+
+	to, err := assetCode(rate.ToAssetID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "failed to get exchange rate", 500)
+	}
+
+	now := time.Now()
+	response := &models.FxRateResponse{
+		Id: rate.ID,
+		FromAsset: from,
+		ToAsset: to,
+		Rate: formatRate(rate.Rate),
+		ValidFrom: rate.ValidFrom,
+		Active: !rate.ValidFrom.After(now) && (!rate.ValidUntil.Valid || rate.ValidUntil.Time.After(now)),
+		CreatedAt: rate.CreatedAt,
+	}
+	if rate.ValidUntil.Valid {
+		validUntil := rate.ValidUntil.Time
+		response.ValidUntil = &validUntil
+	}
+	if rate.CreatedBy.Valid {
+		createdBy := uuid.UUID(rate.CreatedBy.Bytes)
+		response.CreatedBy = &createdBy
+	}
+
+	return response, nil
+}
+
+// parseRate reads a rate written as a plain decimal such as "0.9231". The
+// rate is kept exact as a NUMERIC so conversions never go through floats.
+func parseRate(s string) (pgtype.Numeric, error) {
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if whole == "" || strings.Trim(whole, "0123456789") != "" {
+		return pgtype.Numeric{}, models.ErrInvalidRate
+	}
+	if hasPoint && (frac == "" || strings.Trim(frac, "0123456789") != "") {
+		return pgtype.Numeric{}, models.ErrInvalidRate
+	}
+	if len(frac) > models.MaxDecimals {
+		return pgtype.Numeric{}, models.ErrInvalidRate
+	}
+
+	digits, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok || digits.Sign() <= 0 {
+		return pgtype.Numeric{}, models.ErrInvalidRate
+	}
+
+	return pgtype.Numeric{Int: digits, Exp: -int32(len(frac)), Valid: true}, nil
+}
+
+// formatRate writes a rate as the decimal it was set with: a rate set as
+// "1.50" reads back as "1.50".
+func formatRate(rate pgtype.Numeric) string {
+	digits := rate.Int.String()
+	if rate.Exp >= 0 {
+		return digits + strings.Repeat("0", int(rate.Exp))
+	}
+
+	places := int(-rate.Exp)
+	if pad := places + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	point := len(digits) - places
+	return digits[:point] + "." + digits[point:]
+}
+
+// convertAmount prices amount, in minor units of an asset with fromDecimals,
+// in minor units of an asset with toDecimals. The rate is in whole units, so
+// the result is scaled by the difference in decimals and then rounded down to
+// a whole minor unit: a conversion never pays out more than the rate allows.
+func convertAmount(amount int64, rate pgtype.Numeric, fromDecimals int16, toDecimals int16) (int64, error) {
+	result := new(big.Int).Mul(big.NewInt(amount), rate.Int)
+
+	exp := int64(rate.Exp) + int64(toDecimals) - int64(fromDecimals)
+	if exp >= 0 {
+		result.Mul(result, new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil))
+	} else {
+		result.Quo(result, new(big.Int).Exp(big.NewInt(10), big.NewInt(-exp), nil))
+	}
+
+	if !result.IsInt64() || result.Int64() > models.MaxAmount {
+		return 0, models.ErrAmountOutOfRange
+	}
+	if result.Sign() == 0 {
+		return 0, models.ErrConversionTooSmall
+	}
+
+	return result.Int64(), nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// rateOf maps a generated seed to a rate with up to MaxDecimals places. Odd
+// seeds land within two units of the last place of 1, so that converting an
+// amount near MaxAmount between assets with the same decimals lands on
+// either side of the bound.
+func rateOf(seed uint64) pgtype.Numeric {
+	places := int64(seed >> 1 % (models.MaxDecimals + 1))
+	one := new(big.Int).Exp(big.NewInt(10), big.NewInt(places), nil)
+
+	digits := new(big.Int)
+	if seed&1 == 1 {
+		digits.Add(one, big.NewInt(int64(seed>>6%5)-2))
+	} else {
+		digits.SetUint64(seed>>6%(1<<40) + 1)
+	}
+	if digits.Sign() <= 0 {
+		digits.SetInt64(1)
+	}
+
+	return pgtype.Numeric{Int: digits, Exp: -int32(places), Valid: true}
+}
+
+// converted is amount times rate, scaled by the difference in decimals and
+// rounded down, computed exactly.
+func converted(amount int64, rate pgtype.Numeric, fromDecimals int16, toDecimals int16) *big.Int {
+	scale := new(big.Rat).SetFrac(
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(toDecimals)), nil),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(fromDecimals)-int64(rate.Exp)), nil),
+	)
+	exact := new(big.Rat).Mul(new(big.Rat).SetInt(new(big.Int).Mul(big.NewInt(amount), rate.Int)), scale)
+	return new(big.Int).Quo(exact.Num(), exact.Denom())
+}
+
+func TestConvertAmountNearMaxAmount(t *testing.T) {
+	property := func(a uint64, r uint64, d uint64) bool {
+		amount, rate := amountOf(a), rateOf(r)
+		fromDecimals := int16(d % (models.MaxDecimals + 1))
+		toDecimals := fromDecimals
+		if d&(1<<32) != 0 {
+			toDecimals = int16(d >> 8 % (models.MaxDecimals + 1))
+		}
+
+		got, err := convertAmount(amount, rate, fromDecimals, toDecimals)
+		want := converted(amount, rate, fromDecimals, toDecimals)
+		switch {
+		case want.Cmp(big.NewInt(models.MaxAmount)) > 0:
+			return errors.Is(err, models.ErrAmountOutOfRange)
+		case want.Sign() == 0:
+			return errors.Is(err, models.ErrConversionTooSmall)
+		}
+		return err == nil && got == want.Int64()
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -16,6 +16,7 @@ type HealthServiceResponse struct {
 
 type HealthService interface {
 	CheckHealth() HealthServiceResponse
+	CheckReady() HealthServiceResponse
 }
 
 type healthService struct {
@@ -54,5 +55,45 @@ func (s *healthService) CheckHealth() HealthServiceResponse {
 		response.Status = "unhealthy"
 	}
 
+	return response
+}
+
+// CheckReady reports whether this instance should receive traffic. It is not
+// ready while it is shutting down or cannot reach the database.
+func (s *healthService) CheckReady() HealthServiceResponse {
+	response := HealthServiceResponse{
+		Status:      "ready",
+		Timestamp:   time.Now().UTC(),
+		Checks:      map[string]map[string]interface{}{},
+	}
+
+	check := response.Checks
+
+	if !s.srv.Ready() {
+		check["server"] = map[string]interface{}{
+			"status": "draining",
+		}
+		response.Status = "not_ready"
+		return response
+	}
+	check["server"] = map[string]interface{}{
+		"status": "accepting",
+	}
+
+	dbStart := time.Now()
+	if err := s.srv.Database.Pool.Ping(s.ctx); err != nil {
+		check["database"] = map[string]interface{}{
+			"status":        "unhealthy",
+			"response_time": time.Since(dbStart).String(),
+		}
+		response.Status = "not_ready"
+		s.log.Info().Err(err).Msg("readiness check failed")
+	} else {
+		check["database"] = map[string]interface{}{
+			"status":        "healthy",
+			"response_time": time.Since(dbStart).String(),
+		}
+	}
+
 	return response
 }
\ No newline at end of file
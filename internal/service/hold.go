@@ -0,0 +1,452 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (w *walletService) Authorize(input *models.HoldServiceParams) (*models.HoldResponse, error) {
+	query := w.repo.Queries()
+
+	asset, err := w.resolveAsset(query, input.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := input.Amount.MinorUnits(asset.Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := config.DefaultHoldTTL
+	if input.ExpiresIn > 0 {
+		ttl = time.Duration(input.ExpiresIn) * time.Second
+	}
+
+	var hold repository.Hold
+	var available int64
+
+	err = w.repo.WithTransaction(w.ctx, func(q *repository.Queries) error {
+		// lock wallet row
+		wallet, err := q.LockWallet(w.ctx, repository.LockWalletParams{
+			OwnerID: input.UserId,
+			AssetID: asset.ID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.ErrWalletNotFound
+			}
+			return err
+		}
+
+		if err := checkWalletDebit(wallet); err != nil {
+			return err
+		}
+
+		// a retried authorization returns the hold it already created, as
+		// long as it asks for the same hold
+		existing, err := q.GetHoldById(w.ctx, input.HoldId)
+		if err == nil {
+			if err := w.checkHoldRetry(q, existing, wallet, amount, ttl); err != nil {
+				return err
+			}
+			hold = existing
+			_, available, err = w.availableBalance(q, wallet.ID)
+			return err
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		// release holds that ran out before checking what is reserved
+		if err := q.ExpireHolds(w.ctx, wallet.ID); err != nil {
+			return err
+		}
+
+		_, available, err = w.availableBalance(q, wallet.ID)
+		if err != nil {
+			return err
+		}
+
+		if available < amount {
+			return models.ErrInsufficientBalance
+		}
+
+		// a hold reserves money for a spend, so it may not reserve more than
+		// the spend limits leave; Capture checks again against what was
+		// spent since
+		err = w.limits().check(q, input.UserId, wallet, asset, repository.TransactionTypeSPEND, amount)
+		if err != nil {
+			return err
+		}
+
+		// both timestamps come from one clock so a retry can compare the
+		// hold's lifetime exactly
+		now := time.Now()
+		hold, err = q.CreateHold(w.ctx, repository.CreateHoldParams{
+			ID: input.HoldId,
+			WalletID: wallet.ID,
+			Amount: amount,
+			ExpiresAt: now.Add(ttl),
+			CreatedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+		available -= amount
+
+		return nil
+	})
+
+	if err != nil {
+		w.log.Error().Err(err).Msg("authorization failed")
+
+		// keep the breach details for the response
+		var limitErr *models.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr
+		}
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "authorization failed", 500)
+	}
+
+	return toHoldResponse(hold, available, asset.Decimals), nil
+}
+
+func (w *walletService) Capture(input *models.CaptureServiceParams) (*models.HoldResponse, error) {
+	query := w.repo.Queries()
+
+	// the amount is resolved before the txn_id is claimed, so a retry that
+	// writes the same amount another way fingerprints the same
+	held, err := query.GetHoldById(w.ctx, input.HoldId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrHoldNotFound
+		}
+		w.log.Error().Err(err).Msg("failed to get hold")
+		return nil, models.NewAppError(err, "capture failed", 500)
+	}
+
+	heldWallet, err := query.GetWalletById(w.ctx, held.WalletID)
+	if err != nil || heldWallet.OwnerID != input.UserId {
+		return nil, models.ErrHoldNotFound
+	}
+
+	asset, err := query.GetAssetById(w.ctx, heldWallet.AssetID)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "capture failed", 500)
+	}
+
+	// a hold's amount never changes, so the full capture can be priced now
+	amount := held.Amount
+	if !input.Amount.IsZero() {
+		amount, err = input.Amount.MinorUnits(asset.Decimals)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key := newIdempotencyKey(input.TxnId, input.UserId, operationCapture, input.HoldId, amount)
+
+	var response models.HoldResponse
+
+	err = w.repo.WithTransaction(w.ctx, func(q *repository.Queries) error {
+		// a retried txn_id replays the response it got the first time
+		replayed, err := w.claimIdempotencyKey(q, key, &response)
+		if err != nil || replayed {
+			return err
+		}
+
+		// lock the hold's wallet before the hold itself, as Void does, so a
+		// capture and a void of the same hold cannot deadlock
+		wallet, err := w.lockHoldWallet(q, input.HoldId, input.UserId)
+		if err != nil {
+			return err
+		}
+
+		if err := checkWalletDebit(wallet); err != nil {
+			return err
+		}
+
+		hold, err := q.LockHold(w.ctx, input.HoldId)
+		if err != nil {
+			return err
+		}
+
+		if err := checkHoldActive(hold); err != nil {
+			return err
+		}
+
+		if amount > hold.Amount {
+			return models.ErrCaptureExceedsHold
+		}
+
+		balance, _, err := w.availableBalance(q, wallet.ID)
+		if err != nil {
+			return err
+		}
+		if balance < amount {
+			return models.ErrInsufficientBalance
+		}
+
+		// the capture is the spend, so it counts towards the limits like one
+		err = w.limits().check(q, input.UserId, wallet, asset, repository.TransactionTypeSPEND, amount)
+		if err != nil {
+			return err
+		}
+
+		// create tnx
+		tnx, err := q.CreateTxn(w.ctx, repository.CreateTxnParams{
+			ID: input.TxnId,
+			Type: repository.TransactionTypeSPEND,
+		})
+		if err != nil {
+			return err
+		}
+
+		// add ledger entry for spend for user account
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: -amount,
+			TransactionID: tnx.ID,
+			WalletID: wallet.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// find system account
+		systemWalletId, err := q.GetSystemWallet(w.ctx, wallet.AssetID)
+		if err != nil {
+			return err
+		}
+
+		// add ledger entry for topup for system account
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: amount,
+			TransactionID: tnx.ID,
+			WalletID: systemWalletId,
+		})
+		if err != nil {
+			return err
+		}
+
+		// capturing closes the hold; an uncaptured remainder is released
+		hold, err = q.CaptureHold(w.ctx, repository.CaptureHoldParams{
+			ID: hold.ID,
+			CapturedAmount: amount,
+			CaptureTransactionID: pgtype.UUID{Bytes: tnx.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		_, available, err := w.availableBalance(q, wallet.ID)
+		if err != nil {
+			return err
+		}
+
+		response = *toHoldResponse(hold, available, asset.Decimals)
+
+		return w.saveIdempotentResponse(q, input.TxnId, response)
+	})
+
+	if err != nil {
+		w.log.Error().Err(err).Msg("capture failed")
+
+		// keep the breach details for the response
+		var limitErr *models.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr
+		}
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "capture failed", 500)
+	}
+
+	return &response, nil
+}
+
+func (w *walletService) Void(userId uuid.UUID, holdId uuid.UUID) (*models.HoldResponse, error) {
+	var hold repository.Hold
+	var available int64
+	var asset repository.Asset
+
+	err := w.repo.WithTransaction(w.ctx, func(q *repository.Queries) error {
+		wallet, err := w.lockHoldWallet(q, holdId, userId)
+		if err != nil {
+			return err
+		}
+
+		hold, err = q.LockHold(w.ctx, holdId)
+		if err != nil {
+			return err
+		}
+
+		if err := checkHoldActive(hold); err != nil {
+			return err
+		}
+
+		hold, err = q.VoidHold(w.ctx, holdId)
+		if err != nil {
+			return err
+		}
+
+		asset, err = q.GetAssetById(w.ctx, wallet.AssetID)
+		if err != nil {
+			return err
+		}
+
+		_, available, err = w.availableBalance(q, wallet.ID)
+		return err
+	})
+
+	if err != nil {
+		w.log.Error().Err(err).Msg("void failed")
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "void failed", 500)
+	}
+
+	return toHoldResponse(hold, available, asset.Decimals), nil
+}
+
+func (w *walletService) GetHold(userId uuid.UUID, holdId uuid.UUID) (*models.HoldResponse, error) {
+	query := w.repo.Queries()
+
+	hold, err := query.GetHoldById(w.ctx, holdId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrHoldNotFound
+		}
+		w.log.Error().Err(err).Msg("failed to get hold")
+		return nil, models.NewAppError(err, "failed to retrieve hold", 500)
+	}
+
+	wallet, err := query.GetWalletById(w.ctx, hold.WalletID)
+	if err != nil || wallet.OwnerID != userId {
+		return nil, models.ErrHoldNotFound
+	}
+
+	asset, err := query.GetAssetById(w.ctx, wallet.AssetID)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "failed to retrieve hold", 500)
+	}
+
+	_, available, err := w.availableBalance(query, wallet.ID)
+	if err != nil {
+		return nil, models.ErrBalanceRetrievalFailed
+	}
+
+	return toHoldResponse(hold, available, asset.Decimals), nil
+}
+
+// lockHoldWallet locks the wallet a hold was placed on after checking that
+// the wallet belongs to the caller. Holds of other users are reported as not
+// found so their ids cannot be probed.
+func (w *walletService) lockHoldWallet(q *repository.Queries, holdId uuid.UUID, userId uuid.UUID) (repository.Wallet, error) {
+	hold, err := q.GetHoldById(w.ctx, holdId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.Wallet{}, models.ErrHoldNotFound
+		}
+		return repository.Wallet{}, err
+	}
+
+	wallet, err := q.GetWalletById(w.ctx, hold.WalletID)
+	if err != nil {
+		return repository.Wallet{}, err
+	}
+	if wallet.OwnerID != userId {
+		return repository.Wallet{}, models.ErrHoldNotFound
+	}
+
+	return q.LockWalletById(w.ctx, wallet.ID)
+}
+
+// checkHoldRetry accepts a retried authorization only when it repeats the
+// original one. A hold on another user's wallet is reported as not found so
+// its id cannot be probed.
+func (w *walletService) checkHoldRetry(q *repository.Queries, hold repository.Hold, wallet repository.Wallet, amount int64, ttl time.Duration) error {
+	if hold.WalletID != wallet.ID {
+		holder, err := q.GetWalletById(w.ctx, hold.WalletID)
+		if err != nil {
+			return err
+		}
+		if holder.OwnerID != wallet.OwnerID {
+			return models.ErrHoldNotFound
+		}
+		// the same user's hold in another asset
+		return models.ErrHoldIdMismatch
+	}
+
+	if hold.Amount != amount || hold.ExpiresAt.Sub(hold.CreatedAt) != ttl {
+		return models.ErrHoldIdMismatch
+	}
+
+	return nil
+}
+
+// availableBalance returns the ledger balance of a wallet and the part of it
+// that is not reserved by active, unexpired holds.
+func (w *walletService) availableBalance(q *repository.Queries, walletId uuid.UUID) (int64, int64, error) {
+	balance, err := q.GetBalance(w.ctx, walletId)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	held, err := q.GetActiveHoldsTotal(w.ctx, walletId)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return balance, balance - held, nil
+}
+
+func checkHoldActive(hold repository.Hold) error {
+	if hold.Status != repository.HoldStatusACTIVE {
+		return models.ErrHoldNotActive
+	}
+	if !hold.ExpiresAt.After(time.Now()) {
+		return models.ErrHoldExpired
+	}
+	return nil
+}
+
+func toHoldResponse(hold repository.Hold, available int64, decimals int16) *models.HoldResponse {
+	response := &models.HoldResponse{
+		Id: hold.ID,
+		WalletId: hold.WalletID,
+		Amount: hold.Amount,
+		FormattedAmount: models.FormatAmount(hold.Amount, decimals),
+		CapturedAmount: hold.CapturedAmount,
+		Status: string(hold.Status),
+		ExpiresAt: hold.ExpiresAt,
+		CreatedAt: hold.CreatedAt,
+		AvailableBalance: available,
+		FormattedAvailableBalance: models.FormatAmount(available, decimals),
+	}
+	if hold.CaptureTransactionID.Valid {
+		txnId := uuid.UUID(hold.CaptureTransactionID.Bytes)
+		response.CaptureTransactionId = &txnId
+	}
+	return response
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// The available balance is exact for any balance and hold total a wallet
+// can reach, including more held than the balance after a debit. Both sum
+// amounts of at most MaxAmount, and stay below 1024 times that.
+func TestAvailableBalanceNearMaxAmount(t *testing.T) {
+	w := &walletService{ctx: context.Background(), log: zerolog.Nop()}
+	limit := uint64(1024*models.MaxAmount + 1)
+
+	property := func(b uint64, h uint64) bool {
+		balance := int64(b % limit)
+		held := int64(h % limit)
+
+		q := newFakeQueries(map[string][]any{
+			"GetBalance":          {balance},
+			"GetActiveHoldsTotal": {held},
+		})
+
+		gotBalance, available, err := w.availableBalance(q, uuid.New())
+		want := new(big.Int).Sub(big.NewInt(balance), big.NewInt(held))
+		return err == nil && gotBalance == balance && want.IsInt64() && available == want.Int64()
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	operationTopUp = "TOPUP"
+	operationSpend = "SPEND"
+	operationTransfer = "TRANSFER"
+	operationCapture = "CAPTURE"
+	operationRefund = "REFUND"
+	operationBonus = "BONUS"
+	operationConvert = "CONVERT"
+)
+
+// idempotencyKey describes one client request keyed by its txn_id.
+type idempotencyKey struct {
+	TxnId uuid.UUID
+	UserId uuid.UUID
+	Operation string
+	Fingerprint string
+}
+
+// newIdempotencyKey fingerprints the parts of a request that must not change
+// when the same txn_id is retried.
+func newIdempotencyKey(txnId uuid.UUID, userId uuid.UUID, operation string, parts ...any) idempotencyKey {
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		values = append(values, fmt.Sprint(part))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(values, "|")))
+
+	return idempotencyKey{
+		TxnId: txnId,
+		UserId: userId,
+		Operation: operation,
+		Fingerprint: hex.EncodeToString(sum[:]),
+	}
+}
+
+// claimIdempotencyKey must run first inside the request's database
+// transaction. It returns false when the key is new and the request should be
+// executed. When the key was already used by an identical request it decodes
+// the stored response into replay and returns true. A concurrent retry blocks
+// on the insert until the first request commits or rolls back, so exactly one
+// of them executes.
+func (w *walletService) claimIdempotencyKey(q *repository.Queries, key idempotencyKey, replay any) (bool, error) {
+	claimed, err := q.ClaimIdempotencyKey(w.ctx, repository.ClaimIdempotencyKeyParams{
+		TxnID: key.TxnId,
+		UserID: key.UserId,
+		Operation: key.Operation,
+		Fingerprint: key.Fingerprint,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if claimed == 1 {
+		// ids used before keys were recorded have no response to replay
+		_, err := q.GetTransactionById(w.ctx, key.TxnId)
+		if err == nil {
+			return false, models.ErrDuplicateTransaction
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return false, err
+		}
+		return false, nil
+	}
+
+	stored, err := q.GetIdempotencyKey(w.ctx, key.TxnId)
+	if err != nil {
+		return false, err
+	}
+
+	if stored.UserID != key.UserId || stored.Operation != key.Operation || stored.Fingerprint != key.Fingerprint {
+		return false, models.ErrIdempotencyKeyMismatch
+	}
+
+	if err := json.Unmarshal(stored.ResponseBody, replay); err != nil {
+		return false, err
+	}
+
+	w.log.Debug().Str("txn_id", key.TxnId.String()).Msg("replaying stored response")
+	return true, nil
+}
+
+// saveIdempotentResponse stores the response of a request so that retries of
+// its txn_id replay it. It must run in the same database transaction as
+// claimIdempotencyKey.
+func (w *walletService) saveIdempotentResponse(q *repository.Queries, txnId uuid.UUID, response any) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	return q.SaveIdempotencyResponse(w.ctx, repository.SaveIdempotencyResponseParams{
+		TxnID: txnId,
+		ResponseBody: body,
+	})
+}
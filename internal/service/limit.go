@@ -0,0 +1,417 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+)
+
+const (
+	limitSourceTier = "TIER"
+	limitSourceUser = "USER"
+)
+
+// LimitService manages the limits of one user: their tier, and the per-user
+// rows that replace the tier's limits for an asset and transaction type.
+type LimitService interface {
+	Get(userId uuid.UUID) (*models.UserLimitsResponse, error)
+	SetTier(userId uuid.UUID, tier string) (*models.UserLimitsResponse, error)
+	SetTransactionLimit(userId uuid.UUID, input *models.TransactionLimitRequest) (*models.UserLimitsResponse, error)
+	DeleteTransactionLimit(userId uuid.UUID, assetCode string, txnType string) (*models.UserLimitsResponse, error)
+	SetBalanceLimit(userId uuid.UUID, input *models.BalanceLimitRequest) (*models.UserLimitsResponse, error)
+	DeleteBalanceLimit(userId uuid.UUID, assetCode string) (*models.UserLimitsResponse, error)
+}
+
+type limitService struct {
+	ctx context.Context
+	log zerolog.Logger
+	repo repository.Repository
+}
+
+func (l *limitService) Get(userId uuid.UUID) (*models.UserLimitsResponse, error) {
+	query := l.repo.Queries()
+
+	user, err := query.GetUserById(l.ctx, userId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrUserNotFound
+		}
+		l.log.Error().Err(err).Msg("failed to get user")
+		return nil, models.NewAppError(err, "failed to get limits", 500)
+	}
+
+	return l.respond(query, user)
+}
+
+// SetTier moves a user to another tier. Their own overrides stay in force.
+func (l *limitService) SetTier(userId uuid.UUID, tier string) (*models.UserLimitsResponse, error) {
+	query := l.repo.Queries()
+
+	user, err := query.UpdateUserLimitTier(l.ctx, repository.UpdateUserLimitTierParams{
+		ID: userId,
+		LimitTier: repository.LimitTier(tier),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrUserNotFound
+		}
+		l.log.Error().Err(err).Msg("failed to update limit tier")
+		return nil, models.NewAppError(err, "failed to update limit tier", 500)
+	}
+
+	l.log.Info().Str("user_id", user.ID.String()).Str("tier", string(user.LimitTier)).Msg("user limit tier changed")
+
+	return l.respond(query, user)
+}
+
+func (l *limitService) SetTransactionLimit(userId uuid.UUID, input *models.TransactionLimitRequest) (*models.UserLimitsResponse, error) {
+	query := l.repo.Queries()
+
+	user, asset, err := l.resolve(query, userId, input.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = query.UpsertUserTransactionLimit(l.ctx, repository.UpsertUserTransactionLimitParams{
+		UserID: user.ID,
+		AssetID: asset.ID,
+		TransactionType: repository.TransactionType(input.TransactionType),
+		PerTransaction: toInt8(input.PerTransaction),
+		Daily: toInt8(input.Daily),
+		Monthly: toInt8(input.Monthly),
+	})
+	if err != nil {
+		l.log.Error().Err(err).Msg("failed to set transaction limit")
+		return nil, models.NewAppError(err, "failed to set transaction limit", 500)
+	}
+
+	l.log.Info().
+		Str("user_id", user.ID.String()).
+		Str("asset", asset.Code).
+		Str("transaction_type", input.TransactionType).
+		Msg("user transaction limit set")
+
+	return l.respond(query, user)
+}
+
+func (l *limitService) DeleteTransactionLimit(userId uuid.UUID, assetCode string, txnType string) (*models.UserLimitsResponse, error) {
+	query := l.repo.Queries()
+
+	user, asset, err := l.resolve(query, userId, assetCode)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := query.DeleteUserTransactionLimit(l.ctx, repository.DeleteUserTransactionLimitParams{
+		UserID: user.ID,
+		AssetID: asset.ID,
+		TransactionType: repository.TransactionType(txnType),
+	})
+	if err != nil {
+		l.log.Error().Err(err).Msg("failed to delete transaction limit")
+		return nil, models.NewAppError(err, "failed to delete transaction limit", 500)
+	}
+	if deleted == 0 {
+		return nil, models.ErrLimitNotFound
+	}
+
+	return l.respond(query, user)
+}
+
+func (l *limitService) SetBalanceLimit(userId uuid.UUID, input *models.BalanceLimitRequest) (*models.UserLimitsResponse, error) {
+	query := l.repo.Queries()
+
+	user, asset, err := l.resolve(query, userId, input.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = query.UpsertUserBalanceLimit(l.ctx, repository.UpsertUserBalanceLimitParams{
+		UserID: user.ID,
+		AssetID: asset.ID,
+		MaxBalance: toInt8(input.MaxBalance),
+	})
+	if err != nil {
+		l.log.Error().Err(err).Msg("failed to set balance limit")
+		return nil, models.NewAppError(err, "failed to set balance limit", 500)
+	}
+
+	l.log.Info().Str("user_id", user.ID.String()).Str("asset", asset.Code).Msg("user balance limit set")
+
+	return l.respond(query, user)
+}
+
+func (l *limitService) DeleteBalanceLimit(userId uuid.UUID, assetCode string) (*models.UserLimitsResponse, error) {
+	query := l.repo.Queries()
+
+	user, asset, err := l.resolve(query, userId, assetCode)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := query.DeleteUserBalanceLimit(l.ctx, repository.DeleteUserBalanceLimitParams{
+		UserID: user.ID,
+		AssetID: asset.ID,
+	})
+	if err != nil {
+		l.log.Error().Err(err).Msg("failed to delete balance limit")
+		return nil, models.NewAppError(err, "failed to delete balance limit", 500)
+	}
+	if deleted == 0 {
+		return nil, models.ErrLimitNotFound
+	}
+
+	return l.respond(query, user)
+}
+
+func (l *limitService) resolve(q *repository.Queries, userId uuid.UUID, assetCode string) (repository.User, repository.Asset, error) {
+	user, err := q.GetUserById(l.ctx, userId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.User{}, repository.Asset{}, models.ErrUserNotFound
+		}
+		l.log.Error().Err(err).Msg("failed to get user")
+		return repository.User{}, repository.Asset{}, models.NewAppError(err, "failed to get user", 500)
+	}
+
+	asset, err := q.GetAssetByCode(l.ctx, assetCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.User{}, repository.Asset{}, models.ErrAssetNotFound
+		}
+		l.log.Error().Err(err).Msg("failed to get asset")
+		return repository.User{}, repository.Asset{}, models.NewAppError(err, "failed to retrieve asset", 500)
+	}
+
+	return user, asset, nil
+}
+
+// respond lists the limits in force for a user. The queries return the
+// user's own row ahead of the tier's for each asset and type, so the first
+// row of each pair wins.
+func (l *limitService) respond(q *repository.Queries, user repository.User) (*models.UserLimitsResponse, error) {
+	txnLimits, err := q.ListTransactionLimitsForUser(l.ctx, user.ID)
+	if err != nil {
+		l.log.Error().Err(err).Msg("failed to list transaction limits")
+		return nil, models.NewAppError(err, "failed to get limits", 500)
+	}
+
+	balanceLimits, err := q.ListBalanceLimitsForUser(l.ctx, user.ID)
+	if err != nil {
+		l.log.Error().Err(err).Msg("failed to list balance limits")
+		return nil, models.NewAppError(err, "failed to get limits", 500)
+	}
+
+	codes := map[uuid.UUID]string{}
+	assetCode := func(assetId uuid.UUID) (string, error) {
+		if code, ok := codes[assetId]; ok {
+			return code, nil
+		}
+		asset, err := q.GetAssetById(l.ctx, assetId)
+		if err != nil {
+			return "", err
+		}
+		codes[assetId] = asset.Code
+		return asset.Code, nil
+	}
+
+	response := &models.UserLimitsResponse{
+		UserId: user.ID,
+		Tier: string(user.LimitTier),
+		TransactionLimits: []models.TransactionLimitResponse{},
+		BalanceLimits: []models.BalanceLimitResponse{},
+	}
+
+	type txnKey struct {
+		assetId uuid.UUID
+		txnType repository.TransactionType
+	}
+	seenTxn := map[txnKey]bool{}
+	for _, limit := range txnLimits {
+		key := txnKey{limit.AssetID, limit.TransactionType}
+		if seenTxn[key] {
+			continue
+		}
+		seenTxn[key] = true
+
+		code, err := assetCode(limit.AssetID)
+		if err != nil {
+			l.log.Error().Err(err).Msg("failed to get asset")
+			return nil, models.NewAppError(err, "failed to get limits", 500)
+		}
+
+		response.TransactionLimits = append(response.TransactionLimits, models.TransactionLimitResponse{
+			Asset: code,
+			TransactionType: string(limit.TransactionType),
+			PerTransaction: fromInt8(limit.PerTransaction),
+			Daily: fromInt8(limit.Daily),
+			Monthly: fromInt8(limit.Monthly),
+			Source: limitSource(limit.UserID),
+		})
+	}
+
+	seenBalance := map[uuid.UUID]bool{}
+	for _, limit := range balanceLimits {
+		if seenBalance[limit.AssetID] {
+			continue
+		}
+		seenBalance[limit.AssetID] = true
+
+		code, err := assetCode(limit.AssetID)
+		if err != nil {
+			l.log.Error().Err(err).Msg("failed to get asset")
+			return nil, models.NewAppError(err, "failed to get limits", 500)
+		}
+
+		response.BalanceLimits = append(response.BalanceLimits, models.BalanceLimitResponse{
+			Asset: code,
+			MaxBalance: fromInt8(limit.MaxBalance),
+			Source: limitSource(limit.UserID),
+		})
+	}
+
+	return response, nil
+}
+
+func limitSource(userId pgtype.UUID) string {
+	if userId.Valid {
+		return limitSourceUser
+	}
+	return limitSourceTier
+}
+
+// limitEngine checks a transaction against the limits of the user it moves
+// money for. It runs inside the caller's database transaction after the
+// wallet's row lock is taken, so the usage it reads cannot change before the
+// ledger entries are posted.
+type limitEngine struct {
+	ctx context.Context
+	log zerolog.Logger
+}
+
+// check refuses amount when it is larger than the per-transaction cap, or
+// would take the day's or month's total of txnType past its cap. TOPUP
+// counts money coming in; every other type counts money going out. Top-ups
+// are also held to the maximum balance.
+func (e *limitEngine) check(q *repository.Queries, userId uuid.UUID, wallet repository.Wallet, asset repository.Asset, txnType repository.TransactionType, amount int64) error {
+	credit := txnType == repository.TransactionTypeTOPUP
+
+	limit, err := q.GetTransactionLimit(e.ctx, repository.GetTransactionLimitParams{
+		AssetID: asset.ID,
+		TransactionType: txnType,
+		UserID: userId,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	if err == nil {
+		breach := func(kind string, cap int64, used int64) error {
+			return &models.LimitExceededError{
+				Err: models.ErrLimitExceeded,
+				Limit: models.LimitBreach{
+					Limit: kind,
+					TransactionType: string(txnType),
+					Asset: asset.Code,
+					Cap: cap,
+					FormattedCap: models.FormatAmount(cap, asset.Decimals),
+					Used: used,
+					FormattedUsed: models.FormatAmount(used, asset.Decimals),
+					Requested: amount,
+					FormattedRequested: models.FormatAmount(amount, asset.Decimals),
+				},
+			}
+		}
+
+		if limit.PerTransaction.Valid && amount > limit.PerTransaction.Int64 {
+			return breach(models.LimitPerTransaction, limit.PerTransaction.Int64, 0)
+		}
+
+		if limit.Daily.Valid || limit.Monthly.Valid {
+			usage, err := q.GetLimitUsage(e.ctx, repository.GetLimitUsageParams{
+				WalletID: wallet.ID,
+				Type: txnType,
+				Credit: credit,
+			})
+			if err != nil {
+				return err
+			}
+
+			// compare against the headroom so a large amount cannot overflow
+			if limit.Daily.Valid && amount > limit.Daily.Int64-usage.Daily {
+				return breach(models.LimitDaily, limit.Daily.Int64, usage.Daily)
+			}
+			if limit.Monthly.Valid && amount > limit.Monthly.Int64-usage.Monthly {
+				return breach(models.LimitMonthly, limit.Monthly.Int64, usage.Monthly)
+			}
+		}
+	}
+
+	if credit {
+		return e.checkBalance(q, userId, wallet, asset, txnType, amount)
+	}
+	return nil
+}
+
+// checkBalance refuses a credit that would take the wallet past the owner's
+// maximum balance.
+func (e *limitEngine) checkBalance(q *repository.Queries, userId uuid.UUID, wallet repository.Wallet, asset repository.Asset, txnType repository.TransactionType, amount int64) error {
+	limit, err := q.GetBalanceLimit(e.ctx, repository.GetBalanceLimitParams{
+		AssetID: asset.ID,
+		UserID: userId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if !limit.MaxBalance.Valid {
+		return nil
+	}
+
+	balance, err := q.GetBalance(e.ctx, wallet.ID)
+	if err != nil {
+		return err
+	}
+
+	if amount > limit.MaxBalance.Int64-balance {
+		return &models.LimitExceededError{
+			Err: models.ErrLimitExceeded,
+			Limit: models.LimitBreach{
+				Limit: models.LimitMaxBalance,
+				TransactionType: string(txnType),
+				Asset: asset.Code,
+				Cap: limit.MaxBalance.Int64,
+				FormattedCap: models.FormatAmount(limit.MaxBalance.Int64, asset.Decimals),
+				Used: balance,
+				FormattedUsed: models.FormatAmount(balance, asset.Decimals),
+				Requested: amount,
+				FormattedRequested: models.FormatAmount(amount, asset.Decimals),
+			},
+		}
+	}
+
+	return nil
+}
+
+func toInt8(value *int64) pgtype.Int8 {
+	if value == nil {
+		return pgtype.Int8{}
+	}
+	return pgtype.Int8{Int64: *value, Valid: true}
+}
+
+func fromInt8(value pgtype.Int8) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	v := value.Int64
+	return &v
+}
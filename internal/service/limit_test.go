@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+)
+
+// amountOf maps a generated seed to an amount in [1, MaxAmount]. Odd seeds
+// land within 1<<16 of MaxAmount, where a careless sum would overflow.
+func amountOf(seed uint64) int64 {
+	if seed&1 == 1 {
+		return models.MaxAmount - int64(seed>>1%(1<<16))
+	}
+	return int64(seed>>1%uint64(models.MaxAmount)) + 1
+}
+
+// usageOf maps a generated seed to a window total or balance anywhere in the
+// BIGINT range, since both sum many amounts. A third of the seeds land within
+// two of the point where amount just fits under the cap, and a third within
+// 1<<16 of the top of the range, where adding amount would overflow.
+func usageOf(seed uint64, cap int64, amount int64) int64 {
+	switch seed % 3 {
+	case 0:
+		return max(cap-amount+int64(seed/3%5)-2, 0)
+	case 1:
+		return math.MaxInt64 - int64(seed/3%(1<<16))
+	}
+	return int64(seed / 3)
+}
+
+// exceeds reports whether used plus amount is over cap, computed exactly.
+func exceeds(used int64, amount int64, cap int64) bool {
+	total := new(big.Int).Add(big.NewInt(used), big.NewInt(amount))
+	return total.Cmp(big.NewInt(cap)) > 0
+}
+
+func breachOf(err error) (models.LimitBreach, bool) {
+	var limitErr *models.LimitExceededError
+	if !errors.As(err, &limitErr) {
+		return models.LimitBreach{}, false
+	}
+	return limitErr.Limit, true
+}
+
+func TestLimitCheckNearMaxAmount(t *testing.T) {
+	engine := &limitEngine{ctx: context.Background(), log: zerolog.Nop()}
+	asset := repository.Asset{ID: uuid.New(), Code: "UC"}
+
+	property := func(c uint64, a uint64, u uint64, monthly bool) bool {
+		cap, amount := amountOf(c), amountOf(a)
+		used := usageOf(u, cap, amount)
+
+		// columns of transaction_limits up to monthly
+		limit := []any{nil, nil, nil, nil, nil, pgtype.Int8{}, pgtype.Int8{}, pgtype.Int8{}}
+		window := models.LimitDaily
+		if monthly {
+			limit[7] = pgtype.Int8{Int64: cap, Valid: true}
+			window = models.LimitMonthly
+		} else {
+			limit[6] = pgtype.Int8{Int64: cap, Valid: true}
+		}
+		q := newFakeQueries(map[string][]any{
+			"GetTransactionLimit": limit,
+			"GetLimitUsage":       {used, used},
+		})
+
+		err := engine.check(q, uuid.New(), repository.Wallet{ID: uuid.New()}, asset, repository.TransactionTypeSPEND, amount)
+		if !exceeds(used, amount, cap) {
+			return err == nil
+		}
+		breach, ok := breachOf(err)
+		return ok && breach.Limit == window && breach.Cap == cap && breach.Used == used && breach.Requested == amount
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBalanceLimitNearMaxAmount(t *testing.T) {
+	engine := &limitEngine{ctx: context.Background(), log: zerolog.Nop()}
+	asset := repository.Asset{ID: uuid.New(), Code: "UC"}
+
+	property := func(c uint64, a uint64, b uint64) bool {
+		cap, amount := amountOf(c), amountOf(a)
+		balance := usageOf(b, cap, amount)
+
+		// no transaction limit, so only the maximum balance applies
+		q := newFakeQueries(map[string][]any{
+			"GetBalanceLimit": {nil, nil, nil, nil, pgtype.Int8{Int64: cap, Valid: true}},
+			"GetBalance":      {balance},
+		})
+
+		err := engine.check(q, uuid.New(), repository.Wallet{ID: uuid.New()}, asset, repository.TransactionTypeTOPUP, amount)
+		if !exceeds(balance, amount, cap) {
+			return err == nil
+		}
+		breach, ok := breachOf(err)
+		return ok && breach.Limit == models.LimitMaxBalance && breach.Cap == cap && breach.Used == balance && breach.Requested == amount
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// loginAttempt is a signin attempt counted against a username or client IP
+// before its password was compared.
+type loginAttempt struct {
+	scope repository.LoginAttemptScope
+	subject string
+	// lockedUntil is the backoff this attempt set for the attempts after it
+	lockedUntil pgtype.Timestamptz
+}
+
+// reserveIPAttempt counts a signin attempt against the client IP before
+// the user is looked up or the password is hashed, and rejects it while the
+// IP is backing off or locked. It is the only count an unknown username
+// gets, so probing for usernames is throttled without storing every name
+// tried. The attempt counts as a failure until it is released.
+func (a *authService) reserveIPAttempt(ipAddress string) (*loginAttempt, error) {
+	if ipAddress == "" {
+		return nil, nil
+	}
+	return a.reserveLoginAttempt(repository.LoginAttemptScopeIP, ipAddress, a.cfg.LoginIPLockoutThreshold)
+}
+
+// reserveUserAttempt counts a signin attempt against an existing username
+// before the password is hashed, and rejects it while the username is
+// backing off or locked, so a locked account costs no bcrypt work. The
+// attempt counts as a failure until the username's failures are cleared.
+func (a *authService) reserveUserAttempt(username string, ipAttempt *loginAttempt) error {
+	if _, err := a.reserveLoginAttempt(repository.LoginAttemptScopeUSERNAME, username, a.cfg.LoginLockoutThreshold); err != nil {
+		// a throttled username is not a failure of the IP
+		if releaseErr := a.releaseLoginAttempt(ipAttempt); releaseErr != nil {
+			a.log.Error().Err(releaseErr).Msg("failed to release signin attempt")
+		}
+		return err
+	}
+	return nil
+}
+
+// reserveLoginAttempt counts one attempt against the subject and sets the
+// backoff it earns in the same transaction. The upsert holds the row lock
+// until then, so concurrent attempts see the count and the backoff of the
+// ones before them instead of all passing the check at once.
+func (a *authService) reserveLoginAttempt(scope repository.LoginAttemptScope, subject string, threshold int) (*loginAttempt, error) {
+	attempt := &loginAttempt{
+		scope: scope,
+		subject: subject,
+	}
+
+	err := a.repo.WithTransaction(a.ctx, func(q *repository.Queries) error {
+		row, err := q.ReserveLoginAttempt(a.ctx, repository.ReserveLoginAttemptParams{
+			Scope: scope,
+			Subject: subject,
+			ResetBefore: time.Now().Add(-config.LoginAttemptWindow),
+		})
+		if err != nil {
+			return err
+		}
+
+		if row.LockedUntil.Valid {
+			if wait := time.Until(row.LockedUntil.Time); wait > 0 {
+				return &models.RetryAfterError{
+					Err: models.ErrTooManyAttempts,
+					RetryAfter: wait,
+				}
+			}
+		}
+
+		delay := loginDelay(int(row.Failures), threshold, a.cfg.LoginBackoffBase, a.cfg.LoginLockoutDuration)
+		if delay == 0 {
+			return nil
+		}
+		if int(row.Failures) == threshold {
+			a.log.Warn().Str("scope", string(scope)).Str("subject", subject).Msg("signin locked after repeated failures")
+		}
+
+		// postgres keeps microseconds; release compares the stored value
+		attempt.lockedUntil = pgtype.Timestamptz{Time: time.Now().Add(delay).Truncate(time.Microsecond), Valid: true}
+		return q.LockLogin(a.ctx, repository.LockLoginParams{
+			Scope: scope,
+			Subject: subject,
+			LockedUntil: attempt.lockedUntil,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+// releaseLoginAttempt takes back a reserved attempt whose password matched,
+// along with the backoff it set if no later attempt replaced it. A row left
+// without failures is deleted, so IPs that only sign in successfully are not
+// stored.
+func (a *authService) releaseLoginAttempt(attempt *loginAttempt) error {
+	if attempt == nil {
+		return nil
+	}
+	return a.repo.WithTransaction(a.ctx, func(q *repository.Queries) error {
+		failures, err := q.ReleaseLoginAttempt(a.ctx, repository.ReleaseLoginAttemptParams{
+			LockedUntil: attempt.lockedUntil,
+			Scope: attempt.scope,
+			Subject: attempt.subject,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if failures > 0 {
+			return nil
+		}
+
+		// the update holds the row lock, so no attempt was counted since
+		return q.ClearLoginAttempts(a.ctx, repository.ClearLoginAttemptsParams{
+			Scope: attempt.scope,
+			Subject: attempt.subject,
+		})
+	})
+}
+
+// clearLoginFailures forgets the failed signins of a username after a
+// successful one. The IP's earlier failures are kept, so one valid account
+// does not reset an attacker's budget.
+func (a *authService) clearLoginFailures(username string) error {
+	return a.repo.Queries().ClearLoginAttempts(a.ctx, repository.ClearLoginAttemptsParams{
+		Scope: repository.LoginAttemptScopeUSERNAME,
+		Subject: username,
+	})
+}
+
+// loginDelay is how long to wait after the given number of failures: none
+// for the first few, then doubling from base, and the full lockout once the
+// threshold is reached. A threshold of 0 disables throttling.
+func loginDelay(failures, threshold int, base, lockout time.Duration) time.Duration {
+	if threshold <= 0 {
+		return 0
+	}
+	if failures >= threshold {
+		return lockout
+	}
+	if failures <= config.LoginFreeAttempts {
+		return 0
+	}
+
+	delay := base
+	for i := config.LoginFreeAttempts + 1; i < failures && delay < lockout; i++ {
+		delay *= 2
+	}
+	if delay > lockout {
+		delay = lockout
+	}
+	return delay
+}
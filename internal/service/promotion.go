@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
+)
+
+// campaignEngine pays campaign rewards inside the caller's database
+// transaction. A reward is a BONUS transaction that credits the user and
+// debits the campaign's wallet, so a campaign never pays out more than it was
+// funded with.
+type campaignEngine struct {
+	ctx context.Context
+	log zerolog.Logger
+}
+
+// grant pays amount from a campaign to a user. The campaign row is locked so
+// that concurrent grants against the same budget serialise, and the claim row
+// makes sure a user receives each campaign at most once.
+func (c *campaignEngine) grant(q *repository.Queries, campaignId uuid.UUID, userId uuid.UUID, amount int64, txnId uuid.UUID) (repository.Transaction, error) {
+	campaign, err := q.LockCampaign(c.ctx, campaignId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.Transaction{}, models.ErrCampaignNotFound
+		}
+		return repository.Transaction{}, err
+	}
+
+	if !campaignRunning(campaign, time.Now()) {
+		return repository.Transaction{}, models.ErrCampaignNotActive
+	}
+
+	claimed, err := q.HasClaimedCampaign(c.ctx, repository.HasClaimedCampaignParams{
+		CampaignID: campaign.ID,
+		UserID: userId,
+	})
+	if err != nil {
+		return repository.Transaction{}, err
+	}
+	if claimed {
+		return repository.Transaction{}, models.ErrCampaignAlreadyClaimed
+	}
+
+	if amount <= 0 {
+		return repository.Transaction{}, models.ErrCampaignAmountRequired
+	}
+	if campaign.Spent+amount > campaign.Budget {
+		return repository.Transaction{}, models.ErrCampaignBudgetExhausted
+	}
+
+	// create the wallet if the user has none in the campaign's asset
+	err = q.EnsureWallet(c.ctx, repository.EnsureWalletParams{
+		OwnerType: repository.WalletOwnerTypeUSER,
+		OwnerID: userId,
+		AssetID: campaign.AssetID,
+	})
+	if err != nil {
+		return repository.Transaction{}, err
+	}
+
+	wallet, err := q.GetWalletByOwner(c.ctx, repository.GetWalletByOwnerParams{
+		OwnerID: userId,
+		AssetID: campaign.AssetID,
+	})
+	if err != nil {
+		return repository.Transaction{}, err
+	}
+
+	// the caller holds the wallet's lock except for referral rewards, which
+	// go to the referrer; a referrer's wallet closing at the same moment can
+	// still receive the reward after its sweep
+	if err := checkWalletCredit(wallet); err != nil {
+		return repository.Transaction{}, err
+	}
+
+	tnx, err := q.CreateTxn(c.ctx, repository.CreateTxnParams{
+		ID: txnId,
+		Type: repository.TransactionTypeBONUS,
+	})
+	if err != nil {
+		return repository.Transaction{}, err
+	}
+
+	// add ledger entry for bonus on user account
+	_, err = q.PostLedger(c.ctx, repository.CreateLedgerParams{
+		Amount: amount,
+		TransactionID: tnx.ID,
+		WalletID: wallet.ID,
+	})
+	if err != nil {
+		return repository.Transaction{}, err
+	}
+
+	// add ledger entry for bonus on campaign account
+	_, err = q.PostLedger(c.ctx, repository.CreateLedgerParams{
+		Amount: -amount,
+		TransactionID: tnx.ID,
+		WalletID: campaign.WalletID,
+	})
+	if err != nil {
+		return repository.Transaction{}, err
+	}
+
+	_, err = q.CreateCampaignClaim(c.ctx, repository.CreateCampaignClaimParams{
+		CampaignID: campaign.ID,
+		UserID: userId,
+		TransactionID: tnx.ID,
+		Amount: amount,
+	})
+	if err != nil {
+		return repository.Transaction{}, err
+	}
+
+	_, err = q.AddCampaignSpent(c.ctx, repository.AddCampaignSpentParams{
+		ID: campaign.ID,
+		Spent: amount,
+	})
+	if err != nil {
+		return repository.Transaction{}, err
+	}
+
+	return tnx, nil
+}
+
+// reward grants an automatic campaign reward. Campaigns that stopped, ran out
+// of budget or were already claimed in the meantime, and wallets that cannot
+// receive funds, are skipped so that they never fail the operation that
+// triggered them.
+func (c *campaignEngine) reward(q *repository.Queries, campaign repository.Campaign, userId uuid.UUID, amount int64) error {
+	_, err := c.grant(q, campaign.ID, userId, amount, uuid.New())
+	if errors.Is(err, models.ErrCampaignNotActive) ||
+		errors.Is(err, models.ErrCampaignAlreadyClaimed) ||
+		errors.Is(err, models.ErrCampaignBudgetExhausted) ||
+		errors.Is(err, models.ErrWalletFrozen) ||
+		errors.Is(err, models.ErrWalletClosed) {
+		c.log.Debug().Err(err).Str("campaign_id", campaign.ID.String()).Msg("skipping campaign reward")
+		return nil
+	}
+	return err
+}
+
+// onSignup pays every running signup campaign to a new user.
+func (c *campaignEngine) onSignup(q *repository.Queries, userId uuid.UUID) error {
+	campaigns, err := q.ListActiveCampaigns(c.ctx, repository.ListActiveCampaignsParams{
+		Rule: repository.CampaignRuleSIGNUPBONUS,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, campaign := range campaigns {
+		if err := c.reward(q, campaign, userId, campaign.Amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// onTopUp runs after the user's top-up leg is posted. On the first top-up of
+// a wallet it pays the asset's match campaigns to the user and its referral
+// campaigns to whoever referred them.
+func (c *campaignEngine) onTopUp(q *repository.Queries, userId uuid.UUID, walletId uuid.UUID, assetId uuid.UUID, amount int64) error {
+	topUps, err := q.CountWalletTopUps(c.ctx, walletId)
+	if err != nil {
+		return err
+	}
+	if topUps != 1 {
+		return nil
+	}
+
+	asset := pgtype.UUID{Bytes: assetId, Valid: true}
+
+	matches, err := q.ListActiveCampaigns(c.ctx, repository.ListActiveCampaignsParams{
+		Rule: repository.CampaignRuleFIRSTTOPUPMATCH,
+		AssetID: asset,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, campaign := range matches {
+		// the match is rounded down to a whole minor unit
+		reward := amount * int64(campaign.MatchPercent) / 100
+		if campaign.MaxReward.Valid && reward > campaign.MaxReward.Int64 {
+			reward = campaign.MaxReward.Int64
+		}
+		if reward <= 0 {
+			continue
+		}
+
+		if err := c.reward(q, campaign, userId, reward); err != nil {
+			return err
+		}
+	}
+
+	user, err := q.GetUserById(c.ctx, userId)
+	if err != nil {
+		return err
+	}
+	if !user.ReferredBy.Valid {
+		return nil
+	}
+
+	referrals, err := q.ListActiveCampaigns(c.ctx, repository.ListActiveCampaignsParams{
+		Rule: repository.CampaignRuleREFERRALREWARD,
+		AssetID: asset,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, campaign := range referrals {
+		if err := c.reward(q, campaign, uuid.UUID(user.ReferredBy.Bytes), campaign.Amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// campaignRunning reports whether a campaign pays out at the given time.
+func campaignRunning(campaign repository.Campaign, now time.Time) bool {
+	if campaign.Status != repository.CampaignStatusACTIVE {
+		return false
+	}
+	if campaign.StartsAt.After(now) {
+		return false
+	}
+	return !campaign.EndsAt.Valid || campaign.EndsAt.Time.After(now)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// ReconcileService checks the ledger invariants that the double-entry model
+// promises and reports every violation it finds.
+type ReconcileService interface {
+	Reconcile() (*models.ReconciliationReport, error)
+}
+
+type reconcileService struct {
+	ctx context.Context
+	log zerolog.Logger
+	repo repository.Repository
+}
+
+// Reconcile runs every check against one snapshot of the database:
+//   - the ledger entries of each transaction sum to zero per asset
+//   - every transaction has ledger entries
+//   - cached balances and checkpoints match the ledger
+//   - no user wallet has a negative balance
+func (s *reconcileService) Reconcile() (*models.ReconciliationReport, error) {
+	report := &models.ReconciliationReport{
+		GeneratedAt: time.Now().UTC(),
+		UnbalancedTransactions: []models.UnbalancedTransaction{},
+		OrphanTransactions: []models.OrphanTransaction{},
+		BalanceMismatches: []models.BalanceMismatch{},
+		NegativeBalances: []models.NegativeBalance{},
+	}
+
+	err := s.repo.WithSnapshot(s.ctx, func(q *repository.Queries) error {
+		unbalanced, err := q.FindUnbalancedTransactions(s.ctx)
+		if err != nil {
+			return err
+		}
+		for _, row := range unbalanced {
+			report.UnbalancedTransactions = append(report.UnbalancedTransactions, models.UnbalancedTransaction{
+				TransactionId: row.TransactionID,
+				TransactionType: string(row.Type),
+				AssetId: row.AssetID,
+				Net: row.Net,
+			})
+		}
+
+		orphans, err := q.FindOrphanTransactions(s.ctx)
+		if err != nil {
+			return err
+		}
+		for _, row := range orphans {
+			report.OrphanTransactions = append(report.OrphanTransactions, models.OrphanTransaction{
+				TransactionId: row.ID,
+				TransactionType: string(row.Type),
+				CreatedAt: row.CreatedAt.Time,
+			})
+		}
+
+		balances, err := q.VerifyWalletBalances(s.ctx)
+		if err != nil {
+			return err
+		}
+		for _, row := range balances {
+			if row.CachedBalance == row.LedgerBalance && row.CheckpointBalance == row.LedgerBalance {
+				continue
+			}
+			report.BalanceMismatches = append(report.BalanceMismatches, models.BalanceMismatch{
+				WalletId: row.WalletID,
+				OwnerType: string(row.OwnerType),
+				AssetId: row.AssetID,
+				CachedBalance: row.CachedBalance,
+				CheckpointBalance: row.CheckpointBalance,
+				LedgerBalance: row.LedgerBalance,
+			})
+		}
+
+		negatives, err := q.FindNegativeUserBalances(s.ctx)
+		if err != nil {
+			return err
+		}
+		for _, row := range negatives {
+			report.NegativeBalances = append(report.NegativeBalances, models.NegativeBalance{
+				WalletId: row.WalletID,
+				UserId: row.OwnerID,
+				AssetId: row.AssetID,
+				Balance: row.Balance,
+			})
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		s.log.Error().Err(err).Msg("reconciliation failed")
+		return nil, models.NewAppError(err, "reconciliation failed", 500)
+	}
+
+	report.Summary = models.ReconciliationSummary{
+		UnbalancedTransactions: len(report.UnbalancedTransactions),
+		OrphanTransactions: len(report.OrphanTransactions),
+		BalanceMismatches: len(report.BalanceMismatches),
+		NegativeBalances: len(report.NegativeBalances),
+	}
+	report.Consistent = report.Summary == models.ReconciliationSummary{}
+
+	if !report.Consistent {
+		s.log.Warn().
+			Int("unbalanced_transactions", report.Summary.UnbalancedTransactions).
+			Int("orphan_transactions", report.Summary.OrphanTransactions).
+			Int("balance_mismatches", report.Summary.BalanceMismatches).
+			Int("negative_balances", report.Summary.NegativeBalances).
+			Msg("ledger invariants violated")
+	}
+
+	return report, nil
+}
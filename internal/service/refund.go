@@ -0,0 +1,192 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/AdityaTote/wallet-service/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Refund pays back all or part of a user's spend. Only operators whose role
+// grants transactions:refund can refund: a spend is final for the user who
+// made it.
+func (w *walletService) Refund(input *models.RefundServiceParams) (*models.RefundResponse, error) {
+	query := w.repo.Queries()
+
+	original, err := query.GetTransactionById(w.ctx, input.TransactionId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrTransactionNotFound
+		}
+		w.log.Error().Err(err).Msg("failed to get transaction")
+		return nil, models.NewAppError(err, "refund failed", 500)
+	}
+
+	if original.Type != repository.TransactionTypeSPEND {
+		return nil, models.ErrTransactionNotRefundable
+	}
+
+	legs, err := query.GetLedgersByTransactionId(w.ctx, original.ID)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to get ledgers for transaction")
+		return nil, models.NewAppError(err, "refund failed", 500)
+	}
+
+	userLeg, err := w.findDebitLeg(query, legs)
+	if err != nil {
+		return nil, err
+	}
+	originalAmount := -userLeg.Amount
+
+	payer, err := query.GetWalletById(w.ctx, userLeg.WalletID)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to get wallet")
+		return nil, models.NewAppError(err, "refund failed", 500)
+	}
+
+	asset, err := query.GetAssetById(w.ctx, payer.AssetID)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to get asset")
+		return nil, models.NewAppError(err, "refund failed", 500)
+	}
+
+	// zero refunds whatever is left, which is only known under the lock
+	var requested int64
+	if !input.Amount.IsZero() {
+		requested, err = input.Amount.MinorUnits(asset.Decimals)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key := newIdempotencyKey(input.TxnId, input.ActorId, operationRefund, original.ID, requested)
+
+	var response models.RefundResponse
+
+	err = w.repo.WithTransaction(w.ctx, func(q *repository.Queries) error {
+		// a retried txn_id replays the response it got the first time
+		replayed, err := w.claimIdempotencyKey(q, key, &response)
+		if err != nil || replayed {
+			return err
+		}
+
+		// lock the payer's wallet before the original transaction's row, so
+		// refunds of different spends from one wallet queue on the wallet
+		wallet, err := q.LockWalletById(w.ctx, userLeg.WalletID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkWalletCredit(wallet); err != nil {
+			return err
+		}
+
+		// concurrent refunds of the same transaction serialise on this row
+		locked, err := q.LockTransaction(w.ctx, original.ID)
+		if err != nil {
+			return err
+		}
+
+		remaining := originalAmount - locked.RefundedAmount
+		amount := remaining
+		if requested != 0 {
+			amount = requested
+		}
+		if amount <= 0 || amount > remaining {
+			return models.ErrRefundExceedsOriginal
+		}
+
+		// create tnx
+		tnx, err := q.CreateChildTxn(w.ctx, repository.CreateChildTxnParams{
+			ID: input.TxnId,
+			Type: repository.TransactionTypeREFUND,
+			ParentTransactionID: pgtype.UUID{Bytes: original.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		// mirror every leg of the original against the same wallet
+		for _, leg := range legs {
+			mirror := amount
+			if leg.Amount > 0 {
+				mirror = -amount
+			}
+
+			_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+				Amount: mirror,
+				TransactionID: tnx.ID,
+				WalletID: leg.WalletID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		updated, err := q.AddRefundedAmount(w.ctx, repository.AddRefundedAmountParams{
+			ID: original.ID,
+			RefundedAmount: amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		balance, _, err := w.availableBalance(q, wallet.ID)
+		if err != nil {
+			return err
+		}
+
+		response = models.RefundResponse{
+			TransactionId: tnx.ID,
+			ParentTransactionId: original.ID,
+			Amount: amount,
+			FormattedAmount: models.FormatAmount(amount, asset.Decimals),
+			OriginalAmount: originalAmount,
+			RefundedAmount: updated.RefundedAmount,
+			Balance: balance,
+			FormattedBalance: models.FormatAmount(balance, asset.Decimals),
+		}
+
+		return w.saveIdempotentResponse(q, input.TxnId, response)
+	})
+
+	if err != nil {
+		w.log.Error().Err(err).Msg("refund failed")
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "refund failed", 500)
+	}
+
+	w.log.Info().
+		Str("transaction_id", response.TransactionId.String()).
+		Str("parent_transaction_id", original.ID.String()).
+		Int64("amount", response.Amount).
+		Str("actor_id", input.ActorId.String()).
+		Msg("transaction refunded")
+
+	return &response, nil
+}
+
+// findDebitLeg returns the ledger row that debited the paying user's wallet.
+func (w *walletService) findDebitLeg(q *repository.Queries, legs []repository.Ledger) (repository.Ledger, error) {
+	for _, leg := range legs {
+		if leg.Amount >= 0 {
+			continue
+		}
+
+		wallet, err := q.GetWalletById(w.ctx, leg.WalletID)
+		if err != nil {
+			return repository.Ledger{}, err
+		}
+		if wallet.OwnerType == repository.WalletOwnerTypeUSER {
+			return leg, nil
+		}
+	}
+
+	return repository.Ledger{}, models.ErrTransactionNotFound
+}
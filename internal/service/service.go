@@ -13,6 +13,13 @@ type Services interface {
 	Health() HealthService
 	Auth() AuthService
 	Wallet() WalletService
+	Balance() BalanceService
+	Reconcile() ReconcileService
+	Campaign() CampaignService
+	Admin() AdminService
+	Limit() LimitService
+	Asset() AssetService
+	Fx() FxService
 }
 
 type service struct {
@@ -46,7 +53,7 @@ func (s *service) Auth() AuthService  {
 		ctx: s.ctx,
 		cfg: s.config,
 		log: s.log,
-		repo: s.repo.Queries(),
+		repo: *s.repo,
 	}
 }
 
@@ -56,4 +63,60 @@ func (s *service) Wallet() WalletService  {
 		log: s.log,
 		repo: *s.repo,
 	}
+}
+
+func (s *service) Balance() BalanceService  {
+	return &balanceService{
+		ctx: s.ctx,
+		log: s.log,
+		repo: *s.repo,
+	}
+}
+
+func (s *service) Reconcile() ReconcileService  {
+	return &reconcileService{
+		ctx: s.ctx,
+		log: s.log,
+		repo: *s.repo,
+	}
+}
+
+func (s *service) Campaign() CampaignService  {
+	return &campaignService{
+		ctx: s.ctx,
+		log: s.log,
+		repo: *s.repo,
+	}
+}
+
+func (s *service) Admin() AdminService  {
+	return &adminService{
+		ctx: s.ctx,
+		log: s.log,
+		repo: *s.repo,
+	}
+}
+
+func (s *service) Limit() LimitService  {
+	return &limitService{
+		ctx: s.ctx,
+		log: s.log,
+		repo: *s.repo,
+	}
+}
+
+func (s *service) Asset() AssetService  {
+	return &assetService{
+		ctx: s.ctx,
+		log: s.log,
+		repo: *s.repo,
+	}
+}
+
+func (s *service) Fx() FxService  {
+	return &fxService{
+		ctx: s.ctx,
+		log: s.log,
+		repo: *s.repo,
+	}
 }
\ No newline at end of file
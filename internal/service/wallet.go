@@ -1,20 +1,35 @@
 package service
 
 import (
+	"bytes"
 	"context"
-	"fmt"
+	"errors"
 
+	"github.com/AdityaTote/wallet-service/internal/config"
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
 	"github.com/AdityaTote/wallet-service/internal/models"
 	"github.com/AdityaTote/wallet-service/internal/repository"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog"
 )
 
 type WalletService interface {
 	TopUp(*models.WalletServiceParams) (*models.WalletResponse, error)
 	Spend(*models.WalletServiceParams) (*models.WalletResponse, error)
-	Balance(userId uuid.UUID, walletId uuid.UUID) (int64, error)
-	// Bonus()
+	Transfer(*models.TransferServiceParams) (*models.WalletResponse, error)
+	Balance(userId uuid.UUID, assetCode string) (*models.BalanceResponse, error)
+	History(*models.TransactionHistoryParams) (*models.TransactionHistoryResponse, error)
+	Authorize(*models.HoldServiceParams) (*models.HoldResponse, error)
+	Capture(*models.CaptureServiceParams) (*models.HoldResponse, error)
+	Void(userId uuid.UUID, holdId uuid.UUID) (*models.HoldResponse, error)
+	GetHold(userId uuid.UUID, holdId uuid.UUID) (*models.HoldResponse, error)
+	Refund(*models.RefundServiceParams) (*models.RefundResponse, error)
+	Quote(*models.QuoteServiceParams) (*models.QuoteResponse, error)
+	GetQuote(userId uuid.UUID, quoteId uuid.UUID) (*models.QuoteResponse, error)
+	Convert(*models.ConvertServiceParams) (*models.ConvertResponse, error)
+	Bonus(*models.BonusServiceParams) (*models.BonusResponse, error)
 }
 
 type walletService struct {
@@ -25,39 +40,57 @@ type walletService struct {
 
 func (w *walletService) TopUp(input *models.WalletServiceParams) (*models.WalletResponse, error) {
 	query := w.repo.Queries()
-	
-	// check the tnx id
-	_, err := query.GetTransactionById(w.ctx, input.TxnId)
-	if err == nil {
-		w.log.Debug().Msg("transaction with id already exist")
 
-		// get balance for wallet
-		balanceInterface, err := query.GetBalance(w.ctx, input.WalletId)
-		if err != nil {
-			w.log.Error().Err(err).Msg("failed to get balance for wallet")
-			return nil, models.ErrBalanceRetrievalFailed
-		}
-		fmt.Println("balanceInterface: ", balanceInterface)
-		balance, ok := balanceInterface.(int64)
-		if !ok {
-			w.log.Error().Msg("invalid balance type")
-			return nil, models.ErrInvalidBalance
-		}
-
-		fmt.Println("balance: ", balance)
+	asset, err := w.resolveAsset(query, input.Asset)
+	if err != nil {
+		return nil, err
+	}
 
-		return &models.WalletResponse{
-			Message: "transaction with id already exist",
-			Balance: balance,
-		}, nil
+	amount, err := input.Amount.MinorUnits(asset.Decimals)
+	if err != nil {
+		return nil, err
 	}
 
-	var balance int64
+	key := newIdempotencyKey(input.TxnId, input.UserId, operationTopUp, asset.ID, amount)
+
+	var response models.WalletResponse
 
 	err = w.repo.WithTransaction(w.ctx, func(q *repository.Queries) error {
-		var ok bool
+		// a retried txn_id replays the response it got the first time
+		replayed, err := w.claimIdempotencyKey(q, key, &response)
+		if err != nil || replayed {
+			return err
+		}
+
+		// an inactive asset still pays out, but takes no new money
+		if asset.Status != repository.AssetStatusACTIVE {
+			return models.ErrAssetInactive
+		}
+
+		// create the wallet on the first top-up in this asset
+		err = q.EnsureWallet(w.ctx, repository.EnsureWalletParams{
+			OwnerType: repository.WalletOwnerTypeUSER,
+			OwnerID: input.UserId,
+			AssetID: asset.ID,
+		})
+		if err != nil {
+			return err
+		}
+
 		// lock wallet row
-		walletId, err := q.LockWallet(w.ctx, input.UserId)
+		wallet, err := q.LockWallet(w.ctx, repository.LockWalletParams{
+			OwnerID: input.UserId,
+			AssetID: asset.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := checkWalletCredit(wallet); err != nil {
+			return err
+		}
+
+		err = w.limits().check(q, input.UserId, wallet, asset, repository.TransactionTypeTOPUP, amount)
 		if err != nil {
 			return err
 		}
@@ -72,23 +105,29 @@ func (w *walletService) TopUp(input *models.WalletServiceParams) (*models.Wallet
 		}
 
 		// add ledger entry for topup for user account
-		_, err = q.CreateLedger(w.ctx, repository.CreateLedgerParams{
-			Amount: int32(input.Amount),
-			WalletID: walletId,
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: amount,
+			WalletID: wallet.ID,
 			TransactionID: tnx.ID,
 		})
 		if err != nil {
 			return err
 		}
 
-		systemWalletId, err := q.GetSystemWallet(w.ctx)
+		// first top-up campaigns lock their rows after the user's wallet
+		err = w.campaigns().onTopUp(q, input.UserId, wallet.ID, asset.ID, amount)
+		if err != nil {
+			return err
+		}
+
+		systemWalletId, err := q.GetSystemWallet(w.ctx, asset.ID)
 		if err != nil {
 			return err
 		}
 
 		// add legder entry for spend for system account
-		_, err = q.CreateLedger(w.ctx, repository.CreateLedgerParams{
-			Amount: -int32(input.Amount),
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: -amount,
 			TransactionID: tnx.ID,
 			WalletID: systemWalletId,
 		})
@@ -96,79 +135,96 @@ func (w *walletService) TopUp(input *models.WalletServiceParams) (*models.Wallet
 			return err
 		}
 
-		balanceInterface, err := q.GetBalance(w.ctx, walletId)
+		balance, err := q.GetBalance(w.ctx, wallet.ID)
 		if err != nil {
 			return err
 		}
-		balance, ok = balanceInterface.(int64)
-		if !ok {
-			return models.ErrInvalidBalance
+
+		response = models.WalletResponse{
+			Message: "topup successful",
+			WalletBalance: models.WalletBalance{
+				Balance: balance,
+				FormattedBalance: models.FormatAmount(balance, asset.Decimals),
+			},
 		}
 
-		return nil
+		return w.saveIdempotentResponse(q, input.TxnId, response)
 	})
 
 	if err != nil {
 		w.log.Error().Err(err).Msg("transaction failed")
+
+		// keep the breach details for the response
+		var limitErr *models.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr
+		}
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
 		return nil, models.NewAppError(err, "transaction failed", 500)
 	}
 
-	return &models.WalletResponse{
-		Message: "topup successful",
-		Balance: balance,
-	}, nil
+	return &response, nil
 }
 
 func (w *walletService) Spend(input *models.WalletServiceParams) (*models.WalletResponse, error) {
 	query := w.repo.Queries()
 
-	// check the tnx id
-	_, err := query.GetTransactionById(w.ctx, input.TxnId)
-	if err == nil {
-		w.log.Debug().Msg("transaction with id already exist")
-
-		// get balance for wallet
-		balanceInterface, err := query.GetBalance(w.ctx, input.WalletId)
-		if err != nil {
-			w.log.Error().Err(err).Msg("failed to get balance for wallet")
-			return nil, models.ErrBalanceRetrievalFailed
-		}
-		balance, ok := balanceInterface.(int64)
-		if !ok {
-			w.log.Error().Msg("invalid balance type")
-			return nil, models.ErrInvalidBalance
-		}
+	asset, err := w.resolveAsset(query, input.Asset)
+	if err != nil {
+		return nil, err
+	}
 
-		return &models.WalletResponse{
-			Message: "transaction with id already exist",
-			Balance: balance,
-		}, nil
+	amount, err := input.Amount.MinorUnits(asset.Decimals)
+	if err != nil {
+		return nil, err
 	}
 
-	var balance int64
+	key := newIdempotencyKey(input.TxnId, input.UserId, operationSpend, asset.ID, amount)
+
+	var response models.WalletResponse
 
 	err = w.repo.WithTransaction(w.ctx, func(q *repository.Queries) error {
-		var ok bool
+		// a retried txn_id replays the response it got the first time
+		replayed, err := w.claimIdempotencyKey(q, key, &response)
+		if err != nil || replayed {
+			return err
+		}
+
 		// lock wallet row
-		walletId, err := q.LockWallet(w.ctx, input.UserId)
+		wallet, err := q.LockWallet(w.ctx, repository.LockWalletParams{
+			OwnerID: input.UserId,
+			AssetID: asset.ID,
+		})
 		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.ErrWalletNotFound
+			}
 			return err
 		}
 
-		// check balance for wallet
-		currBalanceInterface, err := q.GetBalance(w.ctx, walletId)
-		if err != nil {
+		if err := checkWalletDebit(wallet); err != nil {
 			return err
 		}
-		currBalance, ok := currBalanceInterface.(int64)
-		if !ok {
-			return models.ErrInvalidBalance
+
+		// check balance for wallet, leaving funds reserved by holds untouched
+		_, available, err := w.availableBalance(q, wallet.ID)
+		if err != nil {
+			return err
 		}
 
-		if currBalance < input.Amount {
+		if available < amount {
 			return models.ErrInsufficientBalance
 		}
 
+		err = w.limits().check(q, input.UserId, wallet, asset, repository.TransactionTypeSPEND, amount)
+		if err != nil {
+			return err
+		}
+
 		// create tnx
 		tnx, err := q.CreateTxn(w.ctx, repository.CreateTxnParams{
 			ID: input.TxnId,
@@ -179,24 +235,24 @@ func (w *walletService) Spend(input *models.WalletServiceParams) (*models.Wallet
 		}
 
 		// add ledger entry for spend for user account
-		_, err = q.CreateLedger(w.ctx, repository.CreateLedgerParams{
-			Amount: -int32(input.Amount),
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: -amount,
 			TransactionID: tnx.ID,
-			WalletID: walletId,
+			WalletID: wallet.ID,
 		})
 		if err != nil {
 			return err
 		}
 
 		// find system account
-		systemWalletId, err := q.GetSystemWallet(w.ctx)
+		systemWalletId, err := q.GetSystemWallet(w.ctx, asset.ID)
 		if err != nil {
 			return err
 		}
 
 		// add ledger entry for topup for system account
-		_, err = q.CreateLedger(w.ctx, repository.CreateLedgerParams{
-			Amount: int32(input.Amount),
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: amount,
 			TransactionID: tnx.ID,
 			WalletID: systemWalletId,
 		})
@@ -204,44 +260,445 @@ func (w *walletService) Spend(input *models.WalletServiceParams) (*models.Wallet
 			return err
 		}
 
-		balanceInterface, err := q.GetBalance(w.ctx, walletId)
+		balance, err := q.GetBalance(w.ctx, wallet.ID)
 		if err != nil {
 			return err
 		}
-		balance, ok = balanceInterface.(int64)
-		if !ok {
-			return models.ErrInvalidBalance
+
+		response = models.WalletResponse{
+			Message: "spend successful",
+			WalletBalance: models.WalletBalance{
+				Balance: balance,
+				FormattedBalance: models.FormatAmount(balance, asset.Decimals),
+			},
 		}
 
-		return nil
+		return w.saveIdempotentResponse(q, input.TxnId, response)
 	})
 
 	if err != nil {
 		w.log.Error().Err(err).Msg("transaction failed")
+
+		// keep the breach details for the response
+		var limitErr *models.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr
+		}
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
 		return nil, models.NewAppError(err, "transaction failed", 500)
 	}
 
-	return &models.WalletResponse{
-		Message: "spend successful",
-		Balance: balance,
-	}, nil
+	return &response, nil
 }
 
-func (w *walletService) Balance(userId uuid.UUID, walletId uuid.UUID) (int64, error) {
+func (w *walletService) Transfer(input *models.TransferServiceParams) (*models.WalletResponse, error) {
+	query := w.repo.Queries()
+
+	asset, err := w.resolveAsset(query, input.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := input.Amount.MinorUnits(asset.Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := query.GetWalletByOwner(w.ctx, repository.GetWalletByOwnerParams{
+		OwnerID: input.UserId,
+		AssetID: asset.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrWalletNotFound
+		}
+		w.log.Error().Err(err).Msg("failed to get sender wallet")
+		return nil, models.NewAppError(err, "transaction failed", 500)
+	}
+
+	recipient := input.RecipientUsername
+	if input.RecipientWalletId != nil {
+		recipient = input.RecipientWalletId.String()
+	}
+	key := newIdempotencyKey(input.TxnId, input.UserId, operationTransfer, asset.ID, amount, recipient)
+
+	var response models.WalletResponse
+
+	err = w.repo.WithTransaction(w.ctx, func(q *repository.Queries) error {
+		// a retried txn_id replays the response it got the first time
+		replayed, err := w.claimIdempotencyKey(q, key, &response)
+		if err != nil || replayed {
+			return err
+		}
+
+		recipientWalletId, err := w.resolveRecipientWallet(q, input, asset.ID)
+		if err != nil {
+			return err
+		}
+
+		if recipientWalletId == sender.ID {
+			return models.ErrSelfTransfer
+		}
+
+		// lock both wallet rows in id order so that two opposite transfers
+		// between the same pair of wallets cannot deadlock
+		first, second := sender.ID, recipientWalletId
+		if bytes.Compare(second[:], first[:]) < 0 {
+			first, second = second, first
+		}
+		var recipientWallet repository.Wallet
+		for _, walletId := range []uuid.UUID{first, second} {
+			locked, err := q.LockWalletById(w.ctx, walletId)
+			if err != nil {
+				return err
+			}
+
+			if locked.ID == sender.ID {
+				// later checks see the state and tier as of the lock
+				sender = locked
+				if err := checkWalletDebit(locked); err != nil {
+					return err
+				}
+			} else if err := checkWalletCredit(locked); err != nil {
+				// the caller learns only that the recipient cannot be paid
+				return models.ErrRecipientUnavailable
+			} else {
+				recipientWallet = locked
+			}
+		}
+
+		// check balance for sender wallet, leaving funds reserved by holds untouched
+		_, available, err := w.availableBalance(q, sender.ID)
+		if err != nil {
+			return err
+		}
+
+		if available < amount {
+			return models.ErrInsufficientBalance
+		}
+
+		err = w.limits().check(q, input.UserId, sender, asset, repository.TransactionTypeTRANSFER, amount)
+		if err != nil {
+			return err
+		}
+
+		// the recipient's maximum balance applies too, but their limits are
+		// not the caller's business
+		err = w.limits().checkBalance(q, recipientWallet.OwnerID, recipientWallet, asset, repository.TransactionTypeTRANSFER, amount)
+		if err != nil {
+			var limitErr *models.LimitExceededError
+			if errors.As(err, &limitErr) {
+				return models.ErrRecipientLimitExceeded
+			}
+			return err
+		}
+
+		// create tnx
+		tnx, err := q.CreateTxn(w.ctx, repository.CreateTxnParams{
+			ID: input.TxnId,
+			Type: repository.TransactionTypeTRANSFER,
+		})
+		if err != nil {
+			return err
+		}
+
+		// add ledger entry for debit on sender account
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: -amount,
+			TransactionID: tnx.ID,
+			WalletID: sender.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// add ledger entry for credit on recipient account
+		_, err = q.PostLedger(w.ctx, repository.CreateLedgerParams{
+			Amount: amount,
+			TransactionID: tnx.ID,
+			WalletID: recipientWalletId,
+		})
+		if err != nil {
+			return err
+		}
+
+		balance, err := q.GetBalance(w.ctx, sender.ID)
+		if err != nil {
+			return err
+		}
+
+		response = models.WalletResponse{
+			Message: "transfer successful",
+			WalletBalance: models.WalletBalance{
+				Balance: balance,
+				FormattedBalance: models.FormatAmount(balance, asset.Decimals),
+			},
+		}
+
+		return w.saveIdempotentResponse(q, input.TxnId, response)
+	})
+
+	if err != nil {
+		w.log.Error().Err(err).Msg("transaction failed")
+
+		// keep the breach details for the response
+		var limitErr *models.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr
+		}
+
+		var appErr *models.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, models.NewAppError(err, "transaction failed", 500)
+	}
+
+	return &response, nil
+}
+
+// resolveRecipientWallet finds the destination wallet of a transfer either by
+// the recipient's username or by an explicit wallet id. Only user wallets in
+// the transferred asset can receive transfers; a recipient addressed by
+// username gets a wallet for the asset if they do not hold one yet.
+func (w *walletService) resolveRecipientWallet(q *repository.Queries, input *models.TransferServiceParams, assetId uuid.UUID) (uuid.UUID, error) {
+	if input.RecipientWalletId != nil {
+		recipient, err := q.GetWalletById(w.ctx, *input.RecipientWalletId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return uuid.Nil, models.ErrRecipientNotFound
+			}
+			return uuid.Nil, err
+		}
+
+		if recipient.OwnerType != repository.WalletOwnerTypeUSER || recipient.AssetID != assetId {
+			return uuid.Nil, models.ErrRecipientNotFound
+		}
+
+		return recipient.ID, nil
+	}
+
+	user, err := q.GetUserByUsername(w.ctx, input.RecipientUsername)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, models.ErrRecipientNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	err = q.EnsureWallet(w.ctx, repository.EnsureWalletParams{
+		OwnerType: repository.WalletOwnerTypeUSER,
+		OwnerID: user.ID,
+		AssetID: assetId,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	recipient, err := q.GetWalletByOwner(w.ctx, repository.GetWalletByOwnerParams{
+		OwnerID: user.ID,
+		AssetID: assetId,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return recipient.ID, nil
+}
+
+func (w *walletService) Balance(userId uuid.UUID, assetCode string) (*models.BalanceResponse, error) {
 	// get balance for user account
 	query := w.repo.Queries()
 
-	balanceInterface, err := query.GetBalance(w.ctx, walletId)
+	asset, err := w.resolveAsset(query, assetCode)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := query.GetWalletByOwner(w.ctx, repository.GetWalletByOwnerParams{
+		OwnerID: userId,
+		AssetID: asset.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrWalletNotFound
+		}
+		w.log.Error().Err(err).Msg("failed to get wallet")
+		return nil, models.ErrBalanceRetrievalFailed
+	}
+
+	balance, available, err := w.availableBalance(query, wallet.ID)
 	if err != nil {
 		w.log.Error().Err(err).Msg("failed to get balance for wallet")
-		return 0, models.ErrBalanceRetrievalFailed
+		return nil, models.ErrBalanceRetrievalFailed
+	}
+
+	return &models.BalanceResponse{
+		Asset: asset.Code,
+		Status: string(wallet.Status),
+		Decimals: asset.Decimals,
+		Balance: balance,
+		FormattedBalance: models.FormatAmount(balance, asset.Decimals),
+		AvailableBalance: available,
+		FormattedAvailableBalance: models.FormatAmount(available, asset.Decimals),
+	}, nil
+}
+
+func (w *walletService) History(input *models.TransactionHistoryParams) (*models.TransactionHistoryResponse, error) {
+	query := w.repo.Queries()
+
+	asset, err := w.resolveAsset(query, input.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := query.GetWalletByOwner(w.ctx, repository.GetWalletByOwnerParams{
+		OwnerID: input.UserId,
+		AssetID: asset.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrWalletNotFound
+		}
+		w.log.Error().Err(err).Msg("failed to get wallet")
+		return nil, models.NewAppError(err, "failed to retrieve transactions", 500)
+	}
+
+	return w.history(query, wallet.ID, asset.Decimals, &input.TransactionHistoryRequest)
+}
+
+// history reads one page of a wallet's ledger entries. decimals is the
+// wallet asset's, for the formatted amounts.
+//
+// Pages are keyed on the ledger seq rather than created_at, which is the
+// start time of the writing transaction. PostLedger numbers a user wallet's
+// entry while it holds the wallet's balance row lock, so the entries are
+// numbered in commit order and one that commits late cannot land behind a
+// cursor. Only user wallets have a history.
+func (w *walletService) history(query *repository.Queries, walletId uuid.UUID, decimals int16, input *models.TransactionHistoryRequest) (*models.TransactionHistoryResponse, error) {
+	params := repository.GetLedgersByWalletIdParams{
+		WalletID: walletId,
+		SortOrder: input.Order,
+		// fetch one extra row to know whether another page exists
+		RowLimit: input.Limit + 1,
+	}
+	if input.Type != "" {
+		params.TransactionType = repository.NullTransactionType{
+			TransactionType: repository.TransactionType(input.Type),
+			Valid: true,
+		}
+	}
+	if input.From != nil {
+		params.FromTime = pgtype.Timestamptz{Time: *input.From, Valid: true}
+	}
+	if input.To != nil {
+		params.ToTime = pgtype.Timestamptz{Time: *input.To, Valid: true}
+	}
+	if input.CursorSeq != nil {
+		params.CursorSeq = pgtype.Int8{Int64: *input.CursorSeq, Valid: true}
+	}
+
+	rows, err := query.GetLedgersByWalletId(w.ctx, params)
+	if err != nil {
+		w.log.Error().Err(err).Msg("failed to get ledger entries for wallet")
+		return nil, models.NewAppError(err, "failed to retrieve transactions", 500)
 	}
 
-	balance, ok := balanceInterface.(int64)
-	if !ok {
-		w.log.Error().Msg("invalid balance type")
-		return 0, models.ErrInvalidBalance
+	hasMore := len(rows) > int(input.Limit)
+	if hasMore {
+		rows = rows[:input.Limit]
 	}
 
-	return balance, nil
-}
\ No newline at end of file
+	entries := make([]models.TransactionHistoryEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := models.TransactionHistoryEntry{
+			LedgerId: row.ID,
+			TransactionId: row.TransactionID,
+			TransactionType: string(row.TransactionType),
+			Amount: row.Amount,
+			FormattedAmount: models.FormatAmount(row.Amount, decimals),
+			CreatedAt: row.CreatedAt.Time,
+			TransactionCreatedAt: row.TransactionCreatedAt.Time,
+			RefundedAmount: row.RefundedAmount,
+		}
+		if row.ParentTransactionID.Valid {
+			parentId := uuid.UUID(row.ParentTransactionID.Bytes)
+			entry.ParentTransactionId = &parentId
+		}
+		entries = append(entries, entry)
+	}
+
+	response := &models.TransactionHistoryResponse{
+		Entries: entries,
+	}
+	if hasMore {
+		last := rows[len(rows)-1]
+		response.NextCursor = utils.EncodeCursor(last.Seq)
+	}
+
+	return response, nil
+}
+
+// limits returns the engine that enforces transaction and balance limits.
+func (w *walletService) limits() *limitEngine {
+	return &limitEngine{
+		ctx: w.ctx,
+		log: w.log,
+	}
+}
+
+// campaigns returns the engine that pays campaign rewards.
+func (w *walletService) campaigns() *campaignEngine {
+	return &campaignEngine{
+		ctx: w.ctx,
+		log: w.log,
+	}
+}
+
+// checkWalletDebit rejects money leaving a wallet that is frozen or closed.
+// Callers hold the wallet's row lock, so the state cannot change before the
+// ledger entries are posted.
+func checkWalletDebit(wallet repository.Wallet) error {
+	switch wallet.Status {
+	case repository.WalletStatusFROZENDEBIT, repository.WalletStatusFROZENALL:
+		return models.ErrWalletFrozen
+	case repository.WalletStatusCLOSED:
+		return models.ErrWalletClosed
+	}
+	return nil
+}
+
+// checkWalletCredit rejects money entering a wallet that is fully frozen or
+// closed. A FROZEN_DEBIT wallet can still be paid.
+func checkWalletCredit(wallet repository.Wallet) error {
+	switch wallet.Status {
+	case repository.WalletStatusFROZENALL:
+		return models.ErrWalletFrozen
+	case repository.WalletStatusCLOSED:
+		return models.ErrWalletClosed
+	}
+	return nil
+}
+
+// resolveAsset looks up an asset by code, falling back to the default asset
+// when the request does not name one.
+func (w *walletService) resolveAsset(query *repository.Queries, code string) (repository.Asset, error) {
+	if code == "" {
+		code = config.DefaultAssetCode
+	}
+
+	asset, err := query.GetAssetByCode(w.ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.Asset{}, models.ErrAssetNotFound
+		}
+		w.log.Error().Err(err).Msg("failed to get asset")
+		return repository.Asset{}, models.NewAppError(err, "failed to retrieve asset", 500)
+	}
+
+	return asset, nil
+}
@@ -0,0 +1,120 @@
+package validations
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+)
+
+func ValidateRoleInput(r *http.Request, log zerolog.Logger) (*models.RoleRequest, error) {
+	var input_data models.RoleRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	input_data.Role = strings.ToUpper(input_data.Role)
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for role input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatAdminValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func ValidateWalletStatusInput(r *http.Request, log zerolog.Logger) (*models.WalletStatusRequest, error) {
+	var input_data models.WalletStatusRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	input_data.Status = strings.ToUpper(input_data.Status)
+	input_data.Reason = strings.TrimSpace(input_data.Reason)
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for wallet status input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatAdminValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func ValidateWalletCloseInput(r *http.Request, log zerolog.Logger) (*models.WalletCloseRequest, error) {
+	var input_data models.WalletCloseRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	input_data.Reason = strings.TrimSpace(input_data.Reason)
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for wallet close input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatAdminValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func formatAdminValidationError(errs validator.ValidationErrors) error {
+	var errorMessages []string
+
+	for _, err := range errs {
+		switch err.Field() {
+		case "Role":
+			errorMessages = append(errorMessages, "role must be one of USER, SUPPORT, ADMIN, FINANCE")
+		case "Status":
+			errorMessages = append(errorMessages, "status must be one of ACTIVE, FROZEN_DEBIT, FROZEN_ALL")
+		case "Reason":
+			switch err.Tag() {
+			case "required":
+				errorMessages = append(errorMessages, "reason is required")
+			case "max":
+				errorMessages = append(errorMessages, "reason must be at most 500 characters")
+			}
+		}
+	}
+
+	if len(errorMessages) == 0 {
+		return models.ErrInvalidInput
+	}
+
+	return errors.New(strings.Join(errorMessages, ", "))
+}
@@ -0,0 +1,31 @@
+package validations
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/go-playground/validator/v10"
+)
+
+// newAmountValidator returns a validator that checks the digits of a
+// models.Amount against the field's tags, so required, gt and lte read the
+// same as when amounts were plain integers. Whether a decimal fits the asset
+// is only known once the service has looked the asset up.
+func newAmountValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		return field.Interface().(models.Amount).Digits
+	}, models.Amount{})
+	return validate
+}
+
+// decodeError keeps the message of an amount that could not be parsed; any
+// other decode failure is reported as an invalid body.
+func decodeError(err error) error {
+	var appErr *models.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return models.ErrInvalidBody
+}
@@ -0,0 +1,62 @@
+package validations
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+func validateWalletAmount(amount string) (*models.WalletRequest, error) {
+	body := fmt.Sprintf(`{"txn_id": %q, "amount": %s}`, uuid.NewString(), amount)
+	r := httptest.NewRequest("POST", "/api/wallet/topup", strings.NewReader(body))
+	return ValidateWalletInput(r, zerolog.Nop())
+}
+
+func TestWalletAmountInRange(t *testing.T) {
+	property := func(n int64) bool {
+		amount := n % models.MaxAmount
+		if amount < 0 {
+			amount = -amount
+		}
+		amount++
+
+		input, err := validateWalletAmount(fmt.Sprint(amount))
+		return err == nil && input.Amount.Digits == amount
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWalletAmountOutOfRange(t *testing.T) {
+	property := func(n int64) bool {
+		if n <= models.MaxAmount && n > 0 {
+			return true
+		}
+		_, err := validateWalletAmount(fmt.Sprint(n))
+		return err != nil
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+
+	for _, amount := range []string{
+		"0",
+		"-1",
+		"9007199254740992",
+		"9223372036854775807",
+		"9223372036854775808",
+		`"9007199254740992"`,
+		`"90071992547409.92"`,
+	} {
+		if _, err := validateWalletAmount(amount); err == nil {
+			t.Errorf("amount %s was accepted", amount)
+		}
+	}
+}
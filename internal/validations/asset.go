@@ -0,0 +1,97 @@
+package validations
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+)
+
+func ValidateAssetInput(r *http.Request, log zerolog.Logger) (*models.AssetRequest, error) {
+	var input_data models.AssetRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	input_data.Code = strings.ToUpper(input_data.Code)
+	input_data.Name = strings.TrimSpace(input_data.Name)
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for asset input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatAssetValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func ValidateAssetUpdateInput(r *http.Request, log zerolog.Logger) (*models.AssetUpdateRequest, error) {
+	var input_data models.AssetUpdateRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	if input_data.Name != nil {
+		name := strings.TrimSpace(*input_data.Name)
+		input_data.Name = &name
+	}
+	if input_data.Status != nil {
+		status := strings.ToUpper(*input_data.Status)
+		input_data.Status = &status
+	}
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for asset update input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatAssetValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func formatAssetValidationError(errs validator.ValidationErrors) error {
+	var errorMessages []string
+
+	for _, err := range errs {
+		switch err.Field() {
+		case "Code":
+			errorMessages = append(errorMessages, "code is required and must be an alphanumeric code of at most 16 characters")
+		case "Name":
+			errorMessages = append(errorMessages, "name is required and must be at most 100 characters")
+		case "Decimals":
+			errorMessages = append(errorMessages, "decimals must be between 0 and 18")
+		case "Status":
+			errorMessages = append(errorMessages, "status must be ACTIVE or INACTIVE")
+		}
+	}
+
+	if len(errorMessages) == 0 {
+		return models.ErrInvalidInput
+	}
+
+	return errors.New(strings.Join(errorMessages, ", "))
+}
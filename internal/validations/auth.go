@@ -36,6 +36,60 @@ func ValidateAuthInput(r *http.Request, log zerolog.Logger) (*models.UserParams,
 	return &models.UserParams{
 		Username:    input_data.Username,
 		Password: input_data.Password,
+		Referrer: input_data.Referrer,
+		Cookie: input_data.Cookie,
+	}, nil
+}
+
+func ValidateRefreshInput(r *http.Request, log zerolog.Logger) (*models.RefreshParams, error) {
+	var input_data models.RefreshParams
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for refresh input validation")
+		return nil, errors.New("refresh_token is required")
+	}
+
+	return &models.RefreshParams{
+		RefreshToken: input_data.RefreshToken,
+	}, nil
+}
+
+func ValidatePasswordChangeInput(r *http.Request, log zerolog.Logger) (*models.PasswordChangeParams, error) {
+	var input_data models.PasswordChangeParams
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for password change input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &models.PasswordChangeParams{
+		CurrentPassword: input_data.CurrentPassword,
+		NewPassword: input_data.NewPassword,
+		Cookie: input_data.Cookie,
 	}, nil
 }
 
@@ -45,13 +99,15 @@ func formatValidationError(errs validator.ValidationErrors) error {
 	for _, err := range errs {
 		switch err.Field() {
 		case "Username":
-				errorMessages = append(errorMessages, "email is required")
+			errorMessages = append(errorMessages, "username is required")
 		case "Password":
-			if err.Tag() == "required" {
-				errorMessages = append(errorMessages, "password is required")
-			} else if err.Tag() == "min" {
-				errorMessages = append(errorMessages, "password must be at least 8 characters long")
-			}
+			errorMessages = append(errorMessages, "password is required")
+		case "Referrer":
+			errorMessages = append(errorMessages, "referrer must be at most 255 characters long")
+		case "CurrentPassword":
+			errorMessages = append(errorMessages, "current_password is required")
+		case "NewPassword":
+			errorMessages = append(errorMessages, "new_password is required")
 		}
 	}
 		if len(errorMessages) == 0 {
@@ -0,0 +1,156 @@
+package validations
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+)
+
+func ValidateCampaignInput(r *http.Request, log zerolog.Logger) (*models.CampaignRequest, error) {
+	var input_data models.CampaignRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, decodeError(err)
+	}
+
+	validate := newAmountValidator()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for campaign input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatCampaignValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	// each rule needs the field it computes its reward from
+	switch input_data.Rule {
+	case "SIGNUP_BONUS", "REFERRAL_REWARD":
+		if input_data.Amount.IsZero() {
+			return nil, errors.New("amount is required for " + strings.ToLower(input_data.Rule) + " campaigns")
+		}
+	case "FIRST_TOPUP_MATCH":
+		if input_data.MatchPercent == 0 {
+			return nil, errors.New("match_percent is required for first_topup_match campaigns")
+		}
+	}
+
+	if input_data.EndsAt != nil && input_data.StartsAt != nil && !input_data.EndsAt.After(*input_data.StartsAt) {
+		return nil, errors.New("ends_at must be after starts_at")
+	}
+
+	return &models.CampaignRequest{
+		Name: input_data.Name,
+		Rule: input_data.Rule,
+		Asset: strings.ToUpper(input_data.Asset),
+		Amount: input_data.Amount,
+		MatchPercent: input_data.MatchPercent,
+		MaxReward: input_data.MaxReward,
+		Budget: input_data.Budget,
+		StartsAt: input_data.StartsAt,
+		EndsAt: input_data.EndsAt,
+	}, nil
+}
+
+func ValidateCampaignUpdateInput(r *http.Request, log zerolog.Logger) (*models.CampaignUpdateRequest, error) {
+	var input_data models.CampaignUpdateRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, decodeError(err)
+	}
+
+	validate := newAmountValidator()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for campaign update input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatCampaignValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func ValidateCampaignGrantInput(r *http.Request, log zerolog.Logger) (*models.CampaignGrantRequest, error) {
+	var input_data models.CampaignGrantRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, decodeError(err)
+	}
+
+	validate := newAmountValidator()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for campaign grant input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatCampaignValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &models.CampaignGrantRequest{
+		TxnId: input_data.TxnId,
+		UserId: input_data.UserId,
+		Amount: input_data.Amount,
+	}, nil
+}
+
+func formatCampaignValidationError(errs validator.ValidationErrors) error {
+	var errorMessages []string
+
+	for _, err := range errs {
+		switch err.Field() {
+		case "Name":
+			errorMessages = append(errorMessages, "name is required and must be at most 100 characters")
+		case "Rule":
+			errorMessages = append(errorMessages, "rule must be one of SIGNUP_BONUS, FIRST_TOPUP_MATCH, REFERRAL_REWARD")
+		case "Status":
+			errorMessages = append(errorMessages, "status must be ACTIVE or PAUSED")
+		case "Asset":
+			errorMessages = append(errorMessages, "asset must be an alphanumeric asset code")
+		case "Amount":
+			errorMessages = append(errorMessages, "amount must be between 1 and 9007199254740991")
+		case "MatchPercent":
+			errorMessages = append(errorMessages, "match_percent must be between 1 and 100")
+		case "MaxReward":
+			errorMessages = append(errorMessages, "max_reward must be between 1 and 9007199254740991")
+		case "Budget":
+			if err.Tag() == "required" {
+				errorMessages = append(errorMessages, "budget is required")
+			} else {
+				errorMessages = append(errorMessages, "budget must be between 1 and 9007199254740991")
+			}
+		case "TxnId":
+			errorMessages = append(errorMessages, "txn_id is required")
+		case "UserId":
+			errorMessages = append(errorMessages, "user_id is required")
+		}
+	}
+
+	if len(errorMessages) == 0 {
+		return models.ErrInvalidInput
+	}
+
+	return errors.New(strings.Join(errorMessages, ", "))
+}
@@ -0,0 +1,131 @@
+package validations
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+)
+
+func ValidateFxRateInput(r *http.Request, log zerolog.Logger) (*models.FxRateRequest, error) {
+	var input_data models.FxRateRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	input_data.FromAsset = strings.ToUpper(input_data.FromAsset)
+	input_data.ToAsset = strings.ToUpper(input_data.ToAsset)
+	input_data.Rate = strings.TrimSpace(input_data.Rate)
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for fx rate input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatFxValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func ValidateQuoteInput(r *http.Request, log zerolog.Logger) (*models.QuoteRequest, error) {
+	var input_data models.QuoteRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, decodeError(err)
+	}
+
+	validate := newAmountValidator()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for quote input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatFxValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &models.QuoteRequest{
+		FromAsset: strings.ToUpper(input_data.FromAsset),
+		ToAsset: strings.ToUpper(input_data.ToAsset),
+		Amount: input_data.Amount,
+		ExpiresIn: input_data.ExpiresIn,
+	}, nil
+}
+
+func ValidateConvertInput(r *http.Request, log zerolog.Logger) (*models.ConvertRequest, error) {
+	var input_data models.ConvertRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for convert input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatFxValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func formatFxValidationError(errs validator.ValidationErrors) error {
+	var errorMessages []string
+
+	for _, err := range errs {
+		switch err.Field() {
+		case "FromAsset":
+			errorMessages = append(errorMessages, "from_asset is required and must be an alphanumeric asset code")
+		case "ToAsset":
+			errorMessages = append(errorMessages, "to_asset is required and must be an alphanumeric asset code")
+		case "Rate":
+			errorMessages = append(errorMessages, "rate is required and must be a decimal string")
+		case "Amount":
+			if err.Tag() == "required" {
+				errorMessages = append(errorMessages, "amount is required")
+			} else if err.Tag() == "gt" {
+				errorMessages = append(errorMessages, "amount must be greater than 0")
+			} else if err.Tag() == "lte" {
+				errorMessages = append(errorMessages, "amount must be at most 9007199254740991 minor units")
+			}
+		case "ExpiresIn":
+			errorMessages = append(errorMessages, "expires_in must be between 1 and 300 seconds")
+		case "TxnId":
+			errorMessages = append(errorMessages, "txn_id is required")
+		case "QuoteId":
+			errorMessages = append(errorMessages, "quote_id is required")
+		}
+	}
+
+	if len(errorMessages) == 0 {
+		return models.ErrInvalidInput
+	}
+
+	return errors.New(strings.Join(errorMessages, ", "))
+}
@@ -0,0 +1,112 @@
+package validations
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+func ValidateHoldInput(r *http.Request, log zerolog.Logger) (*models.HoldRequest, error) {
+	var input_data models.HoldRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, decodeError(err)
+	}
+
+	validate := newAmountValidator()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for hold input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatHoldValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &models.HoldRequest{
+		HoldId: input_data.HoldId,
+		Amount: input_data.Amount,
+		Asset: strings.ToUpper(input_data.Asset),
+		ExpiresIn: input_data.ExpiresIn,
+	}, nil
+}
+
+func ValidateCaptureInput(r *http.Request, log zerolog.Logger) (*models.CaptureRequest, error) {
+	var input_data models.CaptureRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, decodeError(err)
+	}
+
+	validate := newAmountValidator()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for capture input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatHoldValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &models.CaptureRequest{
+		TxnId: input_data.TxnId,
+		Amount: input_data.Amount,
+	}, nil
+}
+
+// ValidateIdParam parses a UUID route parameter such as {id}.
+func ValidateIdParam(r *http.Request, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(chi.URLParam(r, name))
+	if err != nil {
+		return uuid.Nil, errors.New(name + " must be a valid uuid")
+	}
+	return id, nil
+}
+
+func formatHoldValidationError(errs validator.ValidationErrors) error {
+	var errorMessages []string
+
+	for _, err := range errs {
+		switch err.Field() {
+		case "HoldId":
+			errorMessages = append(errorMessages, "hold_id is required")
+		case "TxnId":
+			errorMessages = append(errorMessages, "txn_id is required")
+		case "Amount":
+			if err.Tag() == "required" {
+				errorMessages = append(errorMessages, "amount is required")
+			} else if err.Tag() == "gt" {
+				errorMessages = append(errorMessages, "amount must be greater than 0")
+			} else if err.Tag() == "lte" {
+				errorMessages = append(errorMessages, "amount must be at most 9007199254740991 minor units")
+			}
+		case "Asset":
+			errorMessages = append(errorMessages, "asset must be an alphanumeric asset code")
+		case "ExpiresIn":
+			errorMessages = append(errorMessages, "expires_in must be between 1 and 604800 seconds")
+		}
+	}
+
+	if len(errorMessages) == 0 {
+		return models.ErrInvalidInput
+	}
+
+	return errors.New(strings.Join(errorMessages, ", "))
+}
@@ -0,0 +1,135 @@
+package validations
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/AdityaTote/wallet-service/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+)
+
+func ValidateLimitTierInput(r *http.Request, log zerolog.Logger) (*models.LimitTierRequest, error) {
+	var input_data models.LimitTierRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	input_data.Tier = strings.ToUpper(input_data.Tier)
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for limit tier input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatLimitValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func ValidateTransactionLimitInput(r *http.Request, log zerolog.Logger) (*models.TransactionLimitRequest, error) {
+	var input_data models.TransactionLimitRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	input_data.Asset = strings.ToUpper(input_data.Asset)
+	input_data.TransactionType = strings.ToUpper(input_data.TransactionType)
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for transaction limit input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatLimitValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func ValidateBalanceLimitInput(r *http.Request, log zerolog.Logger) (*models.BalanceLimitRequest, error) {
+	var input_data models.BalanceLimitRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, models.ErrInvalidBody
+	}
+
+	input_data.Asset = strings.ToUpper(input_data.Asset)
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for balance limit input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatLimitValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+// ValidateTransactionTypeParam reads a transaction type that can carry
+// limits from the URL.
+func ValidateTransactionTypeParam(r *http.Request, name string) (string, error) {
+	txnType := strings.ToUpper(chi.URLParam(r, name))
+	switch txnType {
+	case "TOPUP", "SPEND", "TRANSFER", "CONVERT":
+		return txnType, nil
+	}
+	return "", errors.New(name + " must be one of TOPUP, SPEND, TRANSFER, CONVERT")
+}
+
+func formatLimitValidationError(errs validator.ValidationErrors) error {
+	var errorMessages []string
+
+	for _, err := range errs {
+		switch err.Field() {
+		case "Tier":
+			errorMessages = append(errorMessages, "tier must be one of STANDARD, VERIFIED")
+		case "Asset":
+			errorMessages = append(errorMessages, "asset is required and must be an alphanumeric code")
+		case "TransactionType":
+			errorMessages = append(errorMessages, "transaction_type must be one of TOPUP, SPEND, TRANSFER, CONVERT")
+		case "PerTransaction":
+			errorMessages = append(errorMessages, "per_transaction must be between 1 and 9007199254740991")
+		case "Daily":
+			errorMessages = append(errorMessages, "daily must be between 1 and 9007199254740991")
+		case "Monthly":
+			errorMessages = append(errorMessages, "monthly must be between 1 and 9007199254740991")
+		case "MaxBalance":
+			errorMessages = append(errorMessages, "max_balance must be between 1 and 9007199254740991")
+		}
+	}
+
+	if len(errorMessages) == 0 {
+		return models.ErrInvalidInput
+	}
+
+	return errors.New(strings.Join(errorMessages, ", "))
+}
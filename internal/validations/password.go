@@ -0,0 +1,80 @@
+package validations
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/AdityaTote/wallet-service/internal/config"
+)
+
+// usernamePattern allows 3 to 32 letters, digits, dots, dashes and
+// underscores, starting with a letter or digit
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{2,31}$`)
+
+// ValidateUsername checks the format of the username of a new account.
+// Signin accepts any username, so accounts created before the rules keep
+// working.
+func ValidateUsername(username string) error {
+	if !usernamePattern.MatchString(username) {
+		return errors.New("username must be 3 to 32 letters, digits, dots, dashes or underscores, starting with a letter or digit")
+	}
+	return nil
+}
+
+// ValidatePassword checks a new password against the configured policy:
+// length, character classes and the banned password list. username may be
+// empty when it is not known.
+func ValidatePassword(password, username string, cfg *config.Config) error {
+	var errorMessages []string
+
+	if len(password) < cfg.PasswordMinLength {
+		errorMessages = append(errorMessages, fmt.Sprintf("password must be at least %d characters long", cfg.PasswordMinLength))
+	}
+	if len(password) > config.PasswordMaxLength {
+		errorMessages = append(errorMessages, fmt.Sprintf("password must be at most %d bytes long", config.PasswordMaxLength))
+	}
+	if passwordClasses(password) < cfg.PasswordMinClasses {
+		errorMessages = append(errorMessages, fmt.Sprintf("password must mix at least %d of lowercase letters, uppercase letters, digits and symbols", cfg.PasswordMinClasses))
+	}
+
+	lowered := strings.ToLower(password)
+	if _, banned := cfg.BannedPasswords[lowered]; banned {
+		errorMessages = append(errorMessages, "password is too common")
+	} else if username != "" && strings.Contains(lowered, strings.ToLower(username)) {
+		errorMessages = append(errorMessages, "password must not contain the username")
+	}
+
+	if len(errorMessages) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(errorMessages, ", "))
+}
+
+// passwordClasses counts the character classes used in a password.
+func passwordClasses(password string) int {
+	var lower, upper, digit, symbol bool
+
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, used := range []bool{lower, upper, digit, symbol} {
+		if used {
+			classes++
+		}
+	}
+	return classes
+}
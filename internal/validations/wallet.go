@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/AdityaTote/wallet-service/internal/lib/utils"
 	"github.com/AdityaTote/wallet-service/internal/models"
 	"github.com/go-playground/validator/v10"
 	"github.com/rs/zerolog"
@@ -19,10 +22,10 @@ func ValidateWalletInput(r *http.Request, log zerolog.Logger) (*models.WalletReq
 	dec.DisallowUnknownFields()
 	
 	if err := dec.Decode(&input_data); err != nil {
-		return nil, models.ErrInvalidBody
+		return nil, decodeError(err)
 	}
 
-	validate := validator.New()
+	validate := newAmountValidator()
 
 	err := validate.Struct(input_data)
 	if err != nil {
@@ -37,6 +40,7 @@ func ValidateWalletInput(r *http.Request, log zerolog.Logger) (*models.WalletReq
 	return &models.WalletRequest{
 		TxnId: input_data.TxnId,
 		Amount: input_data.Amount,
+		Asset: strings.ToUpper(input_data.Asset),
 	}, nil
 }
 
@@ -50,9 +54,166 @@ func formatWalletValidationError(errs validator.ValidationErrors) error {
 				errorMessages = append(errorMessages, "amount is required")
 			} else if err.Tag() == "gt" {
 				errorMessages = append(errorMessages, "amount must be greater than 0")
+			} else if err.Tag() == "lte" {
+				errorMessages = append(errorMessages, "amount must be at most 9007199254740991 minor units")
 			}
 		case "TxnId":
 			errorMessages = append(errorMessages, "txn_id is required")
+		case "Asset":
+			errorMessages = append(errorMessages, "asset must be an alphanumeric asset code")
+		}
+	}
+
+	if len(errorMessages) == 0 {
+		return models.ErrInvalidInput
+	}
+
+	return errors.New(strings.Join(errorMessages, ", "))
+}
+func ValidateTransferInput(r *http.Request, log zerolog.Logger) (*models.TransferRequest, error) {
+	var input_data models.TransferRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, decodeError(err)
+	}
+
+	validate := newAmountValidator()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for transfer input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatTransferValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &models.TransferRequest{
+		TxnId: input_data.TxnId,
+		Amount: input_data.Amount,
+		RecipientUsername: input_data.RecipientUsername,
+		RecipientWalletId: input_data.RecipientWalletId,
+		Asset: strings.ToUpper(input_data.Asset),
+	}, nil
+}
+
+func formatTransferValidationError(errs validator.ValidationErrors) error {
+	var errorMessages []string
+	recipientReported := false
+
+	for _, err := range errs {
+		switch err.Field() {
+		case "RecipientUsername", "RecipientWalletId":
+			if recipientReported {
+				continue
+			}
+			recipientReported = true
+			if err.Tag() == "excluded_with" {
+				errorMessages = append(errorMessages, "only one of recipient_username or recipient_wallet_id is allowed")
+			} else {
+				errorMessages = append(errorMessages, "recipient_username or recipient_wallet_id is required")
+			}
+		}
+	}
+
+	if walletErr := formatWalletValidationError(errs); walletErr != models.ErrInvalidInput {
+		errorMessages = append([]string{walletErr.Error()}, errorMessages...)
+	}
+
+	if len(errorMessages) == 0 {
+		return models.ErrInvalidInput
+	}
+
+	return errors.New(strings.Join(errorMessages, ", "))
+}
+
+const (
+	defaultHistoryLimit = 20
+	defaultHistoryOrder = "desc"
+)
+
+func ValidateTransactionHistoryInput(r *http.Request, log zerolog.Logger) (*models.TransactionHistoryRequest, error) {
+	params := r.URL.Query()
+
+	input_data := models.TransactionHistoryRequest{
+		Limit: defaultHistoryLimit,
+		Type:  strings.ToUpper(params.Get("type")),
+		Order: defaultHistoryOrder,
+		Asset: strings.ToUpper(params.Get("asset")),
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		n, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			return nil, errors.New("limit must be a number")
+		}
+		input_data.Limit = int32(n)
+	}
+
+	if order := params.Get("order"); order != "" {
+		input_data.Order = strings.ToLower(order)
+	}
+
+	if from := params.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, errors.New("from must be an RFC3339 timestamp")
+		}
+		input_data.From = &t
+	}
+
+	if to := params.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, errors.New("to must be an RFC3339 timestamp")
+		}
+		input_data.To = &t
+	}
+
+	if input_data.From != nil && input_data.To != nil && !input_data.To.After(*input_data.From) {
+		return nil, errors.New("to must be after from")
+	}
+
+	if cursor := params.Get("cursor"); cursor != "" {
+		seq, err := utils.DecodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		input_data.CursorSeq = &seq
+	}
+
+	validate := validator.New()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for transaction history input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatHistoryValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &input_data, nil
+}
+
+func formatHistoryValidationError(errs validator.ValidationErrors) error {
+	var errorMessages []string
+
+	for _, err := range errs {
+		switch err.Field() {
+		case "Limit":
+			errorMessages = append(errorMessages, "limit must be between 1 and 100")
+		case "Type":
+			errorMessages = append(errorMessages, "type must be one of SPEND, TOPUP, BONUS, TRANSFER, REFUND, CONVERT")
+		case "Order":
+			errorMessages = append(errorMessages, "order must be asc or desc")
+		case "Asset":
+			errorMessages = append(errorMessages, "asset must be an alphanumeric asset code")
 		}
 	}
 
@@ -61,4 +222,32 @@ func formatWalletValidationError(errs validator.ValidationErrors) error {
 	}
 
 	return errors.New(strings.Join(errorMessages, ", "))
-}
\ No newline at end of file
+}
+
+func ValidateRefundInput(r *http.Request, log zerolog.Logger) (*models.RefundRequest, error) {
+	var input_data models.RefundRequest
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&input_data); err != nil {
+		return nil, decodeError(err)
+	}
+
+	validate := newAmountValidator()
+
+	err := validate.Struct(input_data)
+	if err != nil {
+		log.Error().Err(err).Msg("validation failed for refund input validation")
+
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return nil, formatHoldValidationError(validationErrors)
+		}
+		return nil, models.ErrInvalidInput
+	}
+
+	return &models.RefundRequest{
+		TxnId: input_data.TxnId,
+		Amount: input_data.Amount,
+	}, nil
+}
@@ -0,0 +1,2 @@
+-- PostgreSQL cannot drop a value from an enum type. Rolling back would require
+-- recreating transaction_type, which is unsafe once TRANSFER rows exist.
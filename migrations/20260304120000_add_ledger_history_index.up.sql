@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_ledger_wallet_created_at_id ON ledgers(wallet_id, created_at DESC, id DESC);
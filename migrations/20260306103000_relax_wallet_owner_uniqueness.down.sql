@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_wallets_system_asset;
+
+-- Fails if any owner already holds wallets in more than one asset.
+ALTER TABLE wallets ADD CONSTRAINT wallets_owner_id_key UNIQUE (owner_id);
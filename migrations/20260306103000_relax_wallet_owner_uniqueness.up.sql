@@ -0,0 +1,52 @@
+-- A user may now hold one wallet per asset. The (owner_type, owner_id, asset_id)
+-- unique key from the initial schema keeps that at one wallet per asset.
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS wallets_owner_id_key;
+
+-- The initial schema allowed several SYSTEM wallets for one asset. Merge them
+-- into the oldest one before the index below makes it the only one: entries
+-- of one transaction are summed into a single entry, since a transaction has
+-- at most one entry per wallet, and the rest are repointed.
+CREATE TEMP TABLE system_wallet_merge ON COMMIT DROP AS
+SELECT w.id AS wallet_id, k.id AS keep_id
+FROM wallets w
+JOIN LATERAL (
+  SELECT id
+  FROM wallets
+  WHERE owner_type = 'SYSTEM' AND asset_id = w.asset_id
+  ORDER BY created_at, id
+  LIMIT 1
+) k ON true
+WHERE w.owner_type = 'SYSTEM';
+
+CREATE TEMP TABLE system_ledger_merge ON COMMIT DROP AS
+SELECT m.keep_id, l.transaction_id, SUM(l.amount) AS amount,
+  (array_agg(l.id ORDER BY l.wallet_id = m.keep_id DESC, l.id))[1] AS survivor_id
+FROM ledgers l
+JOIN system_wallet_merge m ON m.wallet_id = l.wallet_id
+GROUP BY m.keep_id, l.transaction_id
+HAVING COUNT(*) > 1;
+
+DELETE FROM ledgers l
+USING system_ledger_merge g, system_wallet_merge m
+WHERE l.wallet_id = m.wallet_id
+  AND m.keep_id = g.keep_id
+  AND l.transaction_id = g.transaction_id
+  AND l.id <> g.survivor_id;
+
+UPDATE ledgers l
+SET amount = g.amount, wallet_id = g.keep_id
+FROM system_ledger_merge g
+WHERE l.id = g.survivor_id;
+
+UPDATE ledgers l
+SET wallet_id = m.keep_id
+FROM system_wallet_merge m
+WHERE l.wallet_id = m.wallet_id AND m.wallet_id <> m.keep_id;
+
+DELETE FROM wallets w
+USING system_wallet_merge m
+WHERE w.id = m.wallet_id AND m.wallet_id <> m.keep_id;
+
+-- GetSystemWallet resolves the counterparty wallet by asset, so there must be
+-- at most one SYSTEM wallet per asset.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_system_asset ON wallets(asset_id) WHERE owner_type = 'SYSTEM';
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TYPE hold_status AS ENUM ('ACTIVE', 'CAPTURED', 'VOIDED', 'EXPIRED');
+
+CREATE TABLE holds (
+  id UUID PRIMARY KEY,
+  wallet_id UUID NOT NULL REFERENCES wallets(id),
+  amount BIGINT NOT NULL CHECK (amount > 0),
+  captured_amount BIGINT NOT NULL DEFAULT 0,
+  status hold_status NOT NULL DEFAULT 'ACTIVE',
+  capture_transaction_id UUID REFERENCES transactions(id),
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CHECK (captured_amount >= 0 AND captured_amount <= amount)
+);
+
+CREATE INDEX idx_holds_wallet_active ON holds(wallet_id, expires_at) WHERE status = 'ACTIVE';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_transactions_parent;
+
+ALTER TABLE transactions
+  DROP COLUMN IF EXISTS refunded_amount,
+  DROP COLUMN IF EXISTS parent_transaction_id;
+
+-- PostgreSQL cannot drop a value from an enum type, so REFUND stays in
+-- transaction_type.
@@ -0,0 +1,7 @@
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'REFUND';
+
+ALTER TABLE transactions
+  ADD COLUMN parent_transaction_id UUID REFERENCES transactions(id),
+  ADD COLUMN refunded_amount BIGINT NOT NULL DEFAULT 0 CHECK (refunded_amount >= 0);
+
+CREATE INDEX idx_transactions_parent ON transactions(parent_transaction_id) WHERE parent_transaction_id IS NOT NULL;
@@ -0,0 +1,8 @@
+CREATE TABLE idempotency_keys (
+  txn_id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id),
+  operation TEXT NOT NULL,
+  fingerprint TEXT NOT NULL,
+  response_body JSONB,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS wallet_balance_deltas;
+DROP TABLE IF EXISTS balance_checkpoints;
+DROP TABLE IF EXISTS wallet_balances;
+
+DROP INDEX IF EXISTS idx_ledger_wallet_seq;
+
+ALTER TABLE ledgers DROP COLUMN IF EXISTS seq;
@@ -0,0 +1,39 @@
+-- ADD COLUMN takes an exclusive lock on ledgers, so no entry can be written
+-- between numbering the existing rows and backfilling the cached balances.
+ALTER TABLE ledgers ADD COLUMN seq BIGINT GENERATED ALWAYS AS IDENTITY;
+
+CREATE INDEX idx_ledger_wallet_seq ON ledgers(wallet_id, seq);
+
+CREATE TABLE wallet_balances (
+  wallet_id UUID PRIMARY KEY REFERENCES wallets(id),
+  balance BIGINT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE balance_checkpoints (
+  wallet_id UUID NOT NULL REFERENCES wallets(id),
+  ledger_seq BIGINT NOT NULL,
+  balance BIGINT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (wallet_id, ledger_seq)
+);
+
+-- Shared wallets take a leg of many concurrent requests. Their balance
+-- changes are appended here instead of updating one wallet_balances row,
+-- whose lock would serialize every request of an asset, and are folded into
+-- wallet_balances in the background.
+CREATE TABLE wallet_balance_deltas (
+  id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  wallet_id UUID NOT NULL REFERENCES wallets(id),
+  amount BIGINT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_wallet_balance_deltas_wallet ON wallet_balance_deltas(wallet_id);
+
+INSERT INTO wallet_balances (wallet_id, balance)
+SELECT w.id, COALESCE(SUM(l.amount), 0)
+FROM wallets w
+LEFT JOIN ledgers l ON l.wallet_id = w.id
+GROUP BY w.id;
@@ -0,0 +1,2 @@
+-- PostgreSQL cannot drop a value from an enum type. 'PROMOTION' stays in
+-- wallet_owner_type; the previous migration's down removes every row using it.
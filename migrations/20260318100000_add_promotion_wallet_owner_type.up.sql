@@ -0,0 +1,3 @@
+-- A new enum value cannot be used in the transaction that adds it, so the
+-- promotions wallets are created by the next migration.
+ALTER TYPE wallet_owner_type ADD VALUE IF NOT EXISTS 'PROMOTION';
@@ -0,0 +1,10 @@
+LOCK TABLE ledgers IN SHARE ROW EXCLUSIVE MODE;
+
+-- Removing the promotions wallets leaves bonuses single-sided again, as they
+-- were before the up migration.
+DELETE FROM balance_checkpoints WHERE wallet_id IN (SELECT id FROM wallets WHERE owner_type = 'PROMOTION');
+DELETE FROM wallet_balances WHERE wallet_id IN (SELECT id FROM wallets WHERE owner_type = 'PROMOTION');
+DELETE FROM ledgers WHERE wallet_id IN (SELECT id FROM wallets WHERE owner_type = 'PROMOTION');
+DELETE FROM wallets WHERE owner_type = 'PROMOTION';
+
+DROP INDEX IF EXISTS idx_wallets_promotion_asset;
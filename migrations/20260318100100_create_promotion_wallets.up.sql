@@ -0,0 +1,36 @@
+-- Block ledger writes while historical bonuses are balanced below.
+LOCK TABLE ledgers IN SHARE ROW EXCLUSIVE MODE;
+
+-- Signup bonuses are funded from one PROMOTION wallet per asset. Its owner_id
+-- is the asset id.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_promotion_asset ON wallets(asset_id) WHERE owner_type = 'PROMOTION';
+
+INSERT INTO wallets (owner_type, owner_id, asset_id)
+SELECT 'PROMOTION', a.id, a.id
+FROM assets a
+ON CONFLICT DO NOTHING;
+
+-- Earlier signups credited the bonus with a single ledger entry. Debit the
+-- promotions wallet of the asset so every BONUS transaction sums to zero.
+WITH unbalanced AS (
+  SELECT l.transaction_id, w.asset_id, SUM(l.amount) AS net
+  FROM ledgers l
+  JOIN transactions t ON t.id = l.transaction_id
+  JOIN wallets w ON w.id = l.wallet_id
+  WHERE t.type = 'BONUS'
+  GROUP BY l.transaction_id, w.asset_id
+  HAVING SUM(l.amount) <> 0
+), counter_legs AS (
+  INSERT INTO ledgers (amount, transaction_id, wallet_id, created_at)
+  SELECT (-u.net)::integer, u.transaction_id, p.id, t.created_at
+  FROM unbalanced u
+  JOIN transactions t ON t.id = u.transaction_id
+  JOIN wallets p ON p.asset_id = u.asset_id AND p.owner_type = 'PROMOTION'
+  RETURNING wallet_id, amount
+)
+INSERT INTO wallet_balances (wallet_id, balance)
+SELECT wallet_id, SUM(amount)
+FROM counter_legs
+GROUP BY wallet_id
+ON CONFLICT (wallet_id) DO UPDATE
+SET balance = wallet_balances.balance + EXCLUDED.balance, updated_at = now();
@@ -0,0 +1,2 @@
+-- PostgreSQL cannot drop a value from an enum type. 'CAMPAIGN' stays in
+-- wallet_owner_type; the next migration's down removes every row using it.
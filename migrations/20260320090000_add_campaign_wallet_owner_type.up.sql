@@ -0,0 +1,3 @@
+-- Each campaign draws its budget from its own CAMPAIGN wallet. The value is
+-- added on its own because it cannot be used in the transaction that adds it.
+ALTER TYPE wallet_owner_type ADD VALUE IF NOT EXISTS 'CAMPAIGN';
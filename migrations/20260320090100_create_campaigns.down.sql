@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS campaign_claims;
+DROP TABLE IF EXISTS campaigns;
+
+-- Campaign wallets keep their ledger entries' transactions balanced, so they
+-- are removed together with the entries posted to them.
+DELETE FROM balance_checkpoints WHERE wallet_id IN (SELECT id FROM wallets WHERE owner_type = 'CAMPAIGN');
+DELETE FROM wallet_balances WHERE wallet_id IN (SELECT id FROM wallets WHERE owner_type = 'CAMPAIGN');
+DELETE FROM ledgers WHERE wallet_id IN (SELECT id FROM wallets WHERE owner_type = 'CAMPAIGN');
+DELETE FROM wallets WHERE owner_type = 'CAMPAIGN';
+
+ALTER TABLE users DROP COLUMN IF EXISTS referred_by;
+
+DROP TYPE IF EXISTS campaign_status;
+DROP TYPE IF EXISTS campaign_rule;
@@ -0,0 +1,36 @@
+CREATE TYPE campaign_rule AS ENUM ('SIGNUP_BONUS', 'FIRST_TOPUP_MATCH', 'REFERRAL_REWARD');
+CREATE TYPE campaign_status AS ENUM ('ACTIVE', 'PAUSED', 'ENDED');
+
+ALTER TABLE users ADD COLUMN referred_by UUID REFERENCES users(id);
+
+CREATE TABLE campaigns (
+  id UUID PRIMARY KEY,
+  name TEXT NOT NULL,
+  rule campaign_rule NOT NULL,
+  asset_id UUID NOT NULL REFERENCES assets(id),
+  status campaign_status NOT NULL DEFAULT 'ACTIVE',
+  amount BIGINT NOT NULL DEFAULT 0 CHECK (amount >= 0),
+  match_percent INTEGER NOT NULL DEFAULT 0 CHECK (match_percent >= 0 AND match_percent <= 100),
+  max_reward BIGINT CHECK (max_reward > 0),
+  budget BIGINT NOT NULL CHECK (budget > 0),
+  spent BIGINT NOT NULL DEFAULT 0,
+  wallet_id UUID NOT NULL REFERENCES wallets(id),
+  starts_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  ends_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CHECK (spent >= 0 AND spent <= budget)
+);
+
+CREATE INDEX idx_campaigns_active ON campaigns(rule, asset_id) WHERE status = 'ACTIVE';
+
+CREATE TABLE campaign_claims (
+  campaign_id UUID NOT NULL REFERENCES campaigns(id),
+  user_id UUID NOT NULL REFERENCES users(id),
+  transaction_id UUID NOT NULL REFERENCES transactions(id),
+  amount BIGINT NOT NULL CHECK (amount > 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (campaign_id, user_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS login_attempts;
+
+DROP TYPE IF EXISTS login_attempt_scope;
@@ -0,0 +1,11 @@
+CREATE TYPE login_attempt_scope AS ENUM ('USERNAME', 'IP');
+
+CREATE TABLE login_attempts (
+  scope login_attempt_scope NOT NULL,
+  subject TEXT NOT NULL,
+  failures INTEGER NOT NULL DEFAULT 0 CHECK (failures >= 0),
+  locked_until TIMESTAMPTZ,
+  last_failed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (scope, subject)
+);
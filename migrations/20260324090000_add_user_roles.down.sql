@@ -0,0 +1,5 @@
+ALTER TABLE wallets DROP COLUMN IF EXISTS frozen_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+
+DROP TYPE IF EXISTS user_role;
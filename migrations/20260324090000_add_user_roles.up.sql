@@ -0,0 +1,8 @@
+-- Back-office roles. Every existing account stays a plain user; the first
+-- admin is granted with `wallet-service role <username> ADMIN`.
+CREATE TYPE user_role AS ENUM ('USER', 'SUPPORT', 'ADMIN', 'FINANCE');
+
+ALTER TABLE users ADD COLUMN role user_role NOT NULL DEFAULT 'USER';
+
+-- A frozen wallet still receives funds, but its owner cannot move any out.
+ALTER TABLE wallets ADD COLUMN frozen_at TIMESTAMPTZ;
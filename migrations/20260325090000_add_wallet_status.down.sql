@@ -0,0 +1,10 @@
+-- Wallets in any state but ACTIVE come back frozen.
+ALTER TABLE wallets ADD COLUMN frozen_at TIMESTAMPTZ;
+
+UPDATE wallets SET frozen_at = now() WHERE status <> 'ACTIVE';
+
+DROP TABLE IF EXISTS wallet_status_changes;
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS wallet_status;
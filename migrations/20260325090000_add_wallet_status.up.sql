@@ -0,0 +1,28 @@
+-- Wallet states replace the single freeze flag. FROZEN_DEBIT keeps the old
+-- meaning of a freeze, FROZEN_ALL also refuses credits, and CLOSED is final.
+CREATE TYPE wallet_status AS ENUM ('ACTIVE', 'FROZEN_DEBIT', 'FROZEN_ALL', 'CLOSED');
+
+ALTER TABLE wallets ADD COLUMN status wallet_status NOT NULL DEFAULT 'ACTIVE';
+
+UPDATE wallets SET status = 'FROZEN_DEBIT' WHERE frozen_at IS NOT NULL;
+
+-- Every change of state is kept with the operator who made it and why.
+CREATE TABLE wallet_status_changes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  wallet_id UUID NOT NULL REFERENCES wallets(id),
+  from_status wallet_status NOT NULL,
+  to_status wallet_status NOT NULL,
+  reason TEXT NOT NULL CHECK (reason <> ''),
+  changed_by UUID REFERENCES users(id),
+  sweep_transaction_id UUID REFERENCES transactions(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_wallet_status_changes_wallet ON wallet_status_changes(wallet_id, created_at DESC);
+
+INSERT INTO wallet_status_changes (wallet_id, from_status, to_status, reason, created_at)
+SELECT id, 'ACTIVE', 'FROZEN_DEBIT', 'frozen before wallet states were introduced', frozen_at
+FROM wallets
+WHERE frozen_at IS NOT NULL;
+
+ALTER TABLE wallets DROP COLUMN frozen_at;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS balance_limits;
+
+DROP TABLE IF EXISTS transaction_limits;
+
+ALTER TABLE users DROP COLUMN IF EXISTS limit_tier;
+
+DROP TYPE IF EXISTS limit_tier;
@@ -0,0 +1,58 @@
+-- Transaction limits. Every user is on a tier, and a tier caps each
+-- transaction type per asset by single transaction, calendar day and
+-- calendar month (UTC), and the balance a wallet may hold. A row for a user
+-- replaces their tier's row for that asset and type. A NULL cap means no cap.
+CREATE TYPE limit_tier AS ENUM ('STANDARD', 'VERIFIED');
+
+ALTER TABLE users ADD COLUMN limit_tier limit_tier NOT NULL DEFAULT 'STANDARD';
+
+CREATE TABLE transaction_limits (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tier limit_tier,
+  user_id UUID REFERENCES users(id),
+  asset_id UUID NOT NULL REFERENCES assets(id),
+  transaction_type transaction_type NOT NULL,
+  per_transaction BIGINT CHECK (per_transaction > 0),
+  daily BIGINT CHECK (daily > 0),
+  monthly BIGINT CHECK (monthly > 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK ((tier IS NULL) <> (user_id IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_transaction_limits_tier ON transaction_limits(tier, asset_id, transaction_type) WHERE tier IS NOT NULL;
+CREATE UNIQUE INDEX idx_transaction_limits_user ON transaction_limits(user_id, asset_id, transaction_type) WHERE user_id IS NOT NULL;
+
+CREATE TABLE balance_limits (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tier limit_tier,
+  user_id UUID REFERENCES users(id),
+  asset_id UUID NOT NULL REFERENCES assets(id),
+  max_balance BIGINT CHECK (max_balance > 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK ((tier IS NULL) <> (user_id IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_balance_limits_tier ON balance_limits(tier, asset_id) WHERE tier IS NOT NULL;
+CREATE UNIQUE INDEX idx_balance_limits_user ON balance_limits(user_id, asset_id) WHERE user_id IS NOT NULL;
+
+-- Default tiers for the assets that already exist. cmd/seed writes the same
+-- rows for a fresh database.
+INSERT INTO transaction_limits (tier, asset_id, transaction_type, per_transaction, daily, monthly)
+SELECT t.tier::limit_tier, a.id, t.type::transaction_type, t.per_transaction, t.daily, t.monthly
+FROM assets a
+CROSS JOIN (VALUES
+  ('STANDARD', 'TOPUP', 100000, 200000, 1000000),
+  ('STANDARD', 'SPEND', 50000, 100000, 500000),
+  ('STANDARD', 'TRANSFER', 50000, 100000, 500000),
+  ('VERIFIED', 'TOPUP', 1000000, 2000000, 10000000),
+  ('VERIFIED', 'SPEND', 500000, 1000000, 5000000),
+  ('VERIFIED', 'TRANSFER', 500000, 1000000, 5000000)
+) AS t(tier, type, per_transaction, daily, monthly);
+
+INSERT INTO balance_limits (tier, asset_id, max_balance)
+SELECT t.tier::limit_tier, a.id, t.max_balance
+FROM assets a
+CROSS JOIN (VALUES
+  ('STANDARD', 1000000),
+  ('VERIFIED', 10000000)
+) AS t(tier, max_balance);
@@ -0,0 +1,2 @@
+-- Fails if any entry no longer fits in INTEGER.
+ALTER TABLE ledgers ALTER COLUMN amount TYPE INTEGER;
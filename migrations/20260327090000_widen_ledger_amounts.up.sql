@@ -0,0 +1,3 @@
+-- Ledger amounts were INTEGER while every other amount column is BIGINT, so
+-- an amount over 2,147,483,647 could not be stored without wrapping.
+ALTER TABLE ledgers ALTER COLUMN amount TYPE BIGINT;
@@ -0,0 +1,5 @@
+-- decimals is how many digits of an amount sit after the decimal point:
+-- ledgers store 1250 for 12.50 of an asset with decimals = 2. Existing
+-- assets keep 0, so their stored amounts mean what they always did.
+ALTER TABLE assets
+  ADD COLUMN decimals SMALLINT NOT NULL DEFAULT 0 CHECK (decimals BETWEEN 0 AND 18);
@@ -0,0 +1,5 @@
+ALTER TABLE assets DROP COLUMN IF EXISTS updated_at;
+
+ALTER TABLE assets DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS asset_status;
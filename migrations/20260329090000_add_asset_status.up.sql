@@ -0,0 +1,7 @@
+-- Inactive assets take no new top-ups; money already in them can still be
+-- spent, transferred and read.
+CREATE TYPE asset_status AS ENUM ('ACTIVE', 'INACTIVE');
+
+ALTER TABLE assets
+  ADD COLUMN status asset_status NOT NULL DEFAULT 'ACTIVE',
+  ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
@@ -0,0 +1,2 @@
+-- PostgreSQL cannot drop a value from an enum type. 'CONVERT' and 'TREASURY'
+-- stay; the next migration's down removes every row using them.
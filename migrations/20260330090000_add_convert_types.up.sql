@@ -0,0 +1,4 @@
+-- A new enum value cannot be used in the transaction that adds it, so the
+-- treasury wallets are created by the next migration.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'CONVERT';
+ALTER TYPE wallet_owner_type ADD VALUE IF NOT EXISTS 'TREASURY';
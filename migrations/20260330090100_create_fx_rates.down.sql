@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS fx_quotes;
+DROP TABLE IF EXISTS fx_rates;
+
+-- Removing the treasury wallets leaves the user legs of earlier conversions
+-- in place, so every CONVERT transaction is unbalanced afterwards.
+DELETE FROM balance_checkpoints WHERE wallet_id IN (SELECT id FROM wallets WHERE owner_type = 'TREASURY');
+DELETE FROM wallet_balances WHERE wallet_id IN (SELECT id FROM wallets WHERE owner_type = 'TREASURY');
+DELETE FROM ledgers WHERE wallet_id IN (SELECT id FROM wallets WHERE owner_type = 'TREASURY');
+DELETE FROM wallets WHERE owner_type = 'TREASURY';
+
+DROP INDEX IF EXISTS idx_wallets_treasury_asset;
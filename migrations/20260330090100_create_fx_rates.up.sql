@@ -0,0 +1,43 @@
+-- Conversions between assets pass through one TREASURY wallet per asset, so
+-- the ledger of each asset still sums to zero. Its owner_id is the asset id.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_treasury_asset ON wallets(asset_id) WHERE owner_type = 'TREASURY';
+
+INSERT INTO wallets (owner_type, owner_id, asset_id)
+SELECT 'TREASURY', a.id, a.id
+FROM assets a
+ON CONFLICT DO NOTHING;
+
+-- A rate is how many whole units of to_asset one whole unit of from_asset
+-- buys. Rates are directional and never edited: a new row replaces an old one
+-- from its valid_from on.
+CREATE TABLE fx_rates (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  from_asset_id UUID NOT NULL REFERENCES assets(id),
+  to_asset_id UUID NOT NULL REFERENCES assets(id),
+  rate NUMERIC NOT NULL CHECK (rate > 0),
+  valid_from TIMESTAMPTZ NOT NULL DEFAULT now(),
+  valid_until TIMESTAMPTZ,
+  created_by UUID REFERENCES users(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CHECK (from_asset_id <> to_asset_id),
+  CHECK (valid_until IS NULL OR valid_until >= valid_from)
+);
+
+CREATE INDEX idx_fx_rates_pair ON fx_rates(from_asset_id, to_asset_id, valid_from DESC);
+
+-- A quote locks a rate and both amounts for one user until expires_at. It is
+-- used at most once; transaction_id is set by the conversion that uses it.
+CREATE TABLE fx_quotes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id),
+  rate_id UUID NOT NULL REFERENCES fx_rates(id),
+  from_asset_id UUID NOT NULL REFERENCES assets(id),
+  to_asset_id UUID NOT NULL REFERENCES assets(id),
+  rate NUMERIC NOT NULL,
+  from_amount BIGINT NOT NULL CHECK (from_amount > 0),
+  to_amount BIGINT NOT NULL CHECK (to_amount > 0),
+  expires_at TIMESTAMPTZ NOT NULL,
+  transaction_id UUID UNIQUE REFERENCES transactions(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL schema migrations so that the service
+// binary can apply them without the migrate CLI.
+package migrations
+
+import "embed"
+
+// Files holds every <version>_<name>.up.sql and .down.sql file.
+//
+//go:embed *.sql
+var Files embed.FS